@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// abilityEffects returns the short and long effect texts of an ability in
+// lang, or in English if PokeAPI doesn't have them in lang, with PokeAPI's
+// line breaks collapsed.
+func abilityEffects(ability *abilityResponse, lang string) (string, string) {
+	shorts := make(map[string]string)
+	longs := make(map[string]string)
+	for _, e := range ability.EffectEntries {
+		shorts[e.Language.Name] = strings.Join(strings.Fields(e.ShortEffect), " ")
+		longs[e.Language.Name] = strings.Join(strings.Fields(e.Effect), " ")
+	}
+	return pickLocalized(lang, "", shorts), pickLocalized(lang, "", longs)
+}
+
+func commandAbility(cfg *config, args []string) error {
+	ability, err := fetchAbility(pokeAPIBaseURL+"ability/"+resourceName(args[0]), cfg.Cache)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(ability.Pokemon, func(i, j int) bool {
+		return ability.Pokemon[i].Pokemon.Name < ability.Pokemon[j].Pokemon.Name
+	})
+	if cfg.PipeOutput != nil {
+		names := make([]string, len(ability.Pokemon))
+		for i, p := range ability.Pokemon {
+			names[i] = p.Pokemon.Name
+		}
+		cfg.emit("", names)
+		return nil
+	}
+
+	fmt.Printf("%s (%s)\n", cfg.localize("ability", ability.Name), ability.Generation.Name)
+	short, long := abilityEffects(ability, cfg.Settings.language())
+	if short == "" && long == "" {
+		fmt.Println("No description available")
+	}
+	if short != "" {
+		fmt.Printf("Short effect: %s\n", short)
+	}
+	if long != "" && long != short {
+		fmt.Printf("Effect: %s\n", long)
+	}
+
+	if len(ability.Pokemon) == 0 {
+		return nil
+	}
+	fmt.Printf("Pokemon with %s:\n", cfg.localize("ability", ability.Name))
+	for _, p := range ability.Pokemon {
+		line := "  - " + cfg.localize("pokemon", p.Pokemon.Name)
+		if p.IsHidden {
+			line += " (hidden)"
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -0,0 +1,54 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+const staticFixture = `{
+	"name": "static",
+	"generation": {"name": "generation-iii"},
+	"effect_entries": [
+		{"effect": "Whenever a move makes contact with this Pokemon,\nthe move's user has a 30% chance of being paralyzed.", "short_effect": "Has a 30% chance of paralyzing attacking Pokemon on contact.", "language": {"name": "en"}},
+		{"effect": "Bei Kontakt...", "short_effect": "Kann bei Kontakt paralysieren.", "language": {"name": "de"}}
+	],
+	"pokemon": [
+		{"is_hidden": false, "slot": 1, "pokemon": {"name": "pikachu"}},
+		{"is_hidden": true, "slot": 3, "pokemon": {"name": "electrike"}}
+	]
+}`
+
+func TestAbilityEffects(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"ability/static", []byte(staticFixture))
+
+	ability, err := fetchAbility(pokeAPIBaseURL+"ability/static", cfg.Cache)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	short, long := abilityEffects(ability, "")
+	if short != "Has a 30% chance of paralyzing attacking Pokemon on contact." {
+		t.Errorf("Unexpected short effect: %q", short)
+	}
+	if long != "Whenever a move makes contact with this Pokemon, the move's user has a 30% chance of being paralyzed." {
+		t.Errorf("Unexpected effect: %q", long)
+	}
+}
+
+func TestCommandAbilityEmits(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"ability/static", []byte(staticFixture))
+
+	var output []string
+	cfg.PipeOutput = &output
+	if err := commandAbility(cfg, []string{"Static"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(output) != 2 || output[0] != "electrike" || output[1] != "pikachu" {
+		t.Errorf("Expected [electrike pikachu], got %v", output)
+	}
+}
@@ -0,0 +1,145 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+func commandAlias(cfg *config, args []string) error {
+	if len(args) == 0 {
+		if len(cfg.Settings.Aliases) == 0 {
+			fmt.Println("No aliases defined")
+			return nil
+		}
+		printAliases(cfg)
+		return nil
+	}
+	if len(args) < 2 {
+		return fmt.Errorf("alias command requires a name and a command")
+	}
+
+	name := strings.ToLower(args[0])
+	if err := validateShortcutName(name); err != nil {
+		return err
+	}
+	if _, ok := cfg.Settings.Macros[name]; ok {
+		return fmt.Errorf("%s is already a macro", name)
+	}
+
+	cfg.Settings.Aliases[name] = joinWords(args[1:])
+	if err := cfg.Settings.save(); err != nil {
+		return fmt.Errorf("could not save alias: %w", err)
+	}
+	fmt.Printf("%s is now an alias for %s\n", name, cfg.Settings.Aliases[name])
+	return nil
+}
+
+func commandUnalias(cfg *config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("unalias command requires an alias name")
+	}
+	name := strings.ToLower(args[0])
+	if _, ok := cfg.Settings.Aliases[name]; !ok {
+		return fmt.Errorf("no such alias: %s", name)
+	}
+
+	delete(cfg.Settings.Aliases, name)
+	if err := cfg.Settings.save(); err != nil {
+		return fmt.Errorf("could not save aliases: %w", err)
+	}
+	fmt.Printf("Removed alias %s\n", name)
+	return nil
+}
+
+func commandMacro(cfg *config, args []string) error {
+	if len(args) == 0 {
+		if len(cfg.Settings.Macros) == 0 {
+			fmt.Println("No macros defined")
+			return nil
+		}
+		printMacros(cfg)
+		return nil
+	}
+	if len(args) < 3 || args[1] != "=" {
+		return fmt.Errorf("usage: macro <name> = <command>; <command>...")
+	}
+
+	name := strings.ToLower(args[0])
+	if err := validateShortcutName(name); err != nil {
+		return err
+	}
+	if _, ok := cfg.Settings.Aliases[name]; ok {
+		return fmt.Errorf("%s is already an alias", name)
+	}
+
+	// A body given as a single quoted word is used verbatim, otherwise the
+	// words are joined back together and split on ';' when the macro runs.
+	body := args[2]
+	if len(args) > 3 {
+		body = joinWords(args[2:])
+	}
+
+	cfg.Settings.Macros[name] = body
+	if err := cfg.Settings.save(); err != nil {
+		return fmt.Errorf("could not save macro: %w", err)
+	}
+	fmt.Printf("Defined macro %s = %s\n", name, body)
+	return nil
+}
+
+func commandUnmacro(cfg *config, args []string) error {
+	if len(args) != 1 {
+		return fmt.Errorf("unmacro command requires a macro name")
+	}
+	name := strings.ToLower(args[0])
+	if _, ok := cfg.Settings.Macros[name]; !ok {
+		return fmt.Errorf("no such macro: %s", name)
+	}
+
+	delete(cfg.Settings.Macros, name)
+	if err := cfg.Settings.save(); err != nil {
+		return fmt.Errorf("could not save macros: %w", err)
+	}
+	fmt.Printf("Removed macro %s\n", name)
+	return nil
+}
+
+func printAliases(cfg *config) {
+	if len(cfg.Settings.Aliases) == 0 {
+		return
+	}
+	fmt.Println("Aliases:")
+	for _, name := range sortedKeys(cfg.Settings.Aliases) {
+		fmt.Printf("  %s: %s\n", name, cfg.Settings.Aliases[name])
+	}
+}
+
+func printMacros(cfg *config) {
+	if len(cfg.Settings.Macros) == 0 {
+		return
+	}
+	fmt.Println("Macros:")
+	for _, name := range sortedKeys(cfg.Settings.Macros) {
+		fmt.Printf("  %s = %s\n", name, cfg.Settings.Macros[name])
+	}
+}
+
+func validateShortcutName(name string) error {
+	if _, ok := commands[name]; ok {
+		return fmt.Errorf("%s is a built-in command", name)
+	}
+	if name == "" || strings.ContainsAny(name, " \t'\";=$") {
+		return fmt.Errorf("invalid name: %q", name)
+	}
+	return nil
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
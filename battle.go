@@ -0,0 +1,374 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"sort"
+	"strconv"
+)
+
+const (
+	// maxBattleTurns ends battles between Pokemon that can't hurt each
+	// other in a draw.
+	maxBattleTurns = 100
+	// maxMoveLookups bounds how many moves are fetched while looking for
+	// four damaging ones.
+	maxMoveLookups = 12
+)
+
+type battleMove struct {
+	name        string
+	typeName    string
+	damageClass string
+	power       int
+	// accuracy is a percentage; 0 means the move never misses.
+	accuracy int
+	priority int
+}
+
+// struggle is used by Pokemon that know no damaging moves.
+var struggle = battleMove{
+	name:        "struggle",
+	typeName:    "typeless",
+	damageClass: "physical",
+	power:       50,
+}
+
+type battler struct {
+	name  string
+	types []string
+	level int
+	maxHP int
+	hp    int
+	// stats holds attack, defense, special-attack, special-defense and
+	// speed, keyed by their PokeAPI names.
+	stats map[string]int
+	moves []battleMove
+}
+
+func commandBattle(cfg *config, args []string) error {
+	var owned *OwnedPokemon
+	if len(args) == 2 {
+		var err error
+		owned, err = findOwned(cfg, args[0])
+		if err != nil {
+			return err
+		}
+	} else if members := partyMembers(cfg); len(members) > 0 {
+		owned = members[0]
+	} else {
+		return fmt.Errorf("name one of your Pokemon, or add one to your party with 'party add <pokemon>'")
+	}
+	mine := cfg.Pokedex[owned.Species]
+
+	opponent, err := lookupPokemon(cfg, resourceName(args[len(args)-1]))
+	if err != nil {
+		return err
+	}
+	if err := loadTypeChart(cfg, append(pokemonTypes(mine), pokemonTypes(opponent)...)...); err != nil {
+		return err
+	}
+
+	// Each battle gets its own seed, drawn from the session's random
+	// numbers unless given, so it can be replayed on its own.
+	seed := cfg.Rand.Int63()
+	if value, ok := cfg.flag("seed"); ok {
+		seed, err = strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid seed: %s", value)
+		}
+	}
+	rng := rand.New(rand.NewSource(seed))
+
+	opponentLevel := owned.Level
+	if value, ok := cfg.flag("level"); ok {
+		opponentLevel, err = strconv.Atoi(value)
+		if err != nil || opponentLevel < 1 || opponentLevel > 100 {
+			return fmt.Errorf("invalid level: %s", value)
+		}
+	}
+
+	mineMoves, err := ownedBattleMoves(cfg, owned)
+	if err != nil {
+		return err
+	}
+	opponentMoves, err := chooseBattleMoves(cfg, opponent, opponentLevel)
+	if err != nil {
+		return err
+	}
+
+	a, err := newOwnedBattler(cfg, owned, mineMoves)
+	if err != nil {
+		return err
+	}
+	b := newBattler(opponent, opponentLevel, opponentMoves)
+	b.name = cfg.localize("pokemon", opponent.Name)
+
+	fmt.Printf("Battle seed: %d\n", seed)
+	fmt.Printf("%s (HP %d) vs %s (HP %d)\n", a.name, a.maxHP, b.name, b.maxHP)
+	winner, log := simulateBattle(a, b, cfg.TypeChart, rng)
+	for _, line := range log {
+		fmt.Println(line)
+	}
+	if winner == nil {
+		fmt.Println("The battle ended in a draw!")
+		return nil
+	}
+	fmt.Printf("%s wins!\n", winner.name)
+	if winner == a {
+		gainEffort(owned, opponent)
+		if err := gainExperience(cfg, owned, experienceYield(opponent.BaseExperience, b.level, a.level)); err != nil {
+			return err
+		}
+		if err := saveGame(cfg); err != nil {
+			return fmt.Errorf("could not save battle results: %w", err)
+		}
+	}
+	return nil
+}
+
+// newBattler prepares a wild Pokemon for battle, deriving its stats at
+// level from the base stats with no IVs, EVs or nature.
+func newBattler(p Pokemon, level int, moves []battleMove) *battler {
+	b := &battler{
+		name:  p.Name,
+		level: level,
+		stats: make(map[string]int),
+		moves: moves,
+	}
+	for _, t := range p.Types {
+		b.types = append(b.types, t.Type.Name)
+	}
+	for _, stat := range p.Stats {
+		if stat.Stat.Name == "hp" {
+			b.maxHP = calculateStat(stat.BaseStat, 0, 0, level, 100, true)
+		} else {
+			b.stats[stat.Stat.Name] = calculateStat(stat.BaseStat, 0, 0, level, 100, false)
+		}
+	}
+	if b.maxHP == 0 {
+		b.maxHP = level + 10
+	}
+	b.hp = b.maxHP
+	if len(b.moves) == 0 {
+		b.moves = []battleMove{struggle}
+	}
+	return b
+}
+
+// newOwnedBattler prepares one of the trainer's Pokemon for battle with its
+// actual stats.
+func newOwnedBattler(cfg *config, owned *OwnedPokemon, moves []battleMove) (*battler, error) {
+	stats, err := ownedStats(cfg, owned)
+	if err != nil {
+		return nil, err
+	}
+	b := newBattler(cfg.Pokedex[owned.Species], owned.Level, moves)
+	b.name = owned.displayName(cfg)
+	for name, value := range stats {
+		if name == "hp" {
+			b.maxHP = value
+		} else {
+			b.stats[name] = value
+		}
+	}
+	b.hp = b.maxHP
+	return b, nil
+}
+
+// chooseBattleMoves picks up to four damaging moves known at level,
+// preferring those learned last, the way a wild Pokemon's moves are chosen.
+func chooseBattleMoves(cfg *config, p Pokemon, level int) ([]battleMove, error) {
+	type candidate struct {
+		url   string
+		level int
+	}
+	var candidates []candidate
+	for _, move := range p.Moves {
+		learnedAt := -1
+		for _, detail := range move.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name == "level-up" && detail.LevelLearnedAt > learnedAt && detail.LevelLearnedAt <= level {
+				learnedAt = detail.LevelLearnedAt
+			}
+		}
+		if learnedAt >= 0 {
+			candidates = append(candidates, candidate{url: move.Move.URL, level: learnedAt})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return candidates[i].level > candidates[j].level
+	})
+
+	var moves []battleMove
+	for i, c := range candidates {
+		if len(moves) == 4 || i == maxMoveLookups {
+			break
+		}
+		res, err := fetchMove(c.url, cfg.Cache)
+		if err != nil {
+			return nil, err
+		}
+		if res.Power == nil || *res.Power == 0 {
+			continue
+		}
+		moves = append(moves, toBattleMove(cfg, res))
+	}
+	return moves, nil
+}
+
+// ownedBattleMoves returns the damaging moves an owned Pokemon knows.
+// Pokemon caught before movesets were tracked fight like wild ones.
+func ownedBattleMoves(cfg *config, owned *OwnedPokemon) ([]battleMove, error) {
+	if len(owned.Moves) == 0 {
+		return chooseBattleMoves(cfg, cfg.Pokedex[owned.Species], owned.Level)
+	}
+	var moves []battleMove
+	for _, name := range owned.Moves {
+		res, err := fetchMove(pokeAPIBaseURL+"move/"+name, cfg.Cache)
+		if err != nil {
+			return nil, err
+		}
+		if res.Power != nil && *res.Power > 0 {
+			moves = append(moves, toBattleMove(cfg, res))
+		}
+	}
+	return moves, nil
+}
+
+func toBattleMove(cfg *config, res *moveResponse) battleMove {
+	move := battleMove{
+		name:        cfg.localize("move", res.Name),
+		typeName:    res.Type.Name,
+		damageClass: res.DamageClass.Name,
+		priority:    res.Priority,
+	}
+	if res.Power != nil {
+		move.power = *res.Power
+	}
+	if res.Accuracy != nil {
+		move.accuracy = *res.Accuracy
+	}
+	return move
+}
+
+// simulateBattle fights until one side faints, returning the winner (nil
+// for a draw) and a turn-by-turn log. chart must hold the types of both
+// sides. All randomness comes from rng, so the same seed always produces
+// the same battle.
+func simulateBattle(a, b *battler, chart typeChart, rng *rand.Rand) (*battler, []string) {
+	var log []string
+	for turn := 1; turn <= maxBattleTurns; turn++ {
+		log = append(log, fmt.Sprintf("Turn %d:", turn))
+
+		moveA := a.moves[rng.Intn(len(a.moves))]
+		moveB := b.moves[rng.Intn(len(b.moves))]
+		first, firstMove, second, secondMove := a, moveA, b, moveB
+		if goesSecond(a, moveA, b, moveB, rng) {
+			first, firstMove, second, secondMove = b, moveB, a, moveA
+		}
+
+		log = append(log, useMove(first, second, firstMove, chart, rng))
+		if second.hp == 0 {
+			log = append(log, fmt.Sprintf("  %s fainted!", second.name))
+			return first, log
+		}
+		log = append(log, useMove(second, first, secondMove, chart, rng))
+		if first.hp == 0 {
+			log = append(log, fmt.Sprintf("  %s fainted!", first.name))
+			return second, log
+		}
+	}
+	return nil, log
+}
+
+// goesSecond reports whether a moves after b, by move priority, then
+// speed, then a coin flip.
+func goesSecond(a *battler, moveA battleMove, b *battler, moveB battleMove, rng *rand.Rand) bool {
+	if moveA.priority != moveB.priority {
+		return moveA.priority < moveB.priority
+	}
+	if a.stats["speed"] != b.stats["speed"] {
+		return a.stats["speed"] < b.stats["speed"]
+	}
+	return rng.Intn(2) == 1
+}
+
+func useMove(attacker, defender *battler, move battleMove, chart typeChart, rng *rand.Rand) string {
+	if move.accuracy > 0 && rng.Intn(100) >= move.accuracy {
+		return fmt.Sprintf("  %s used %s, but it missed!", attacker.name, move.name)
+	}
+
+	effectiveness := chart.multiplier(move.typeName, defender.types)
+	if effectiveness == 0 {
+		return fmt.Sprintf("  %s used %s, but it doesn't affect %s...", attacker.name, move.name, defender.name)
+	}
+
+	damage, critical := battleDamage(attacker, defender, move, effectiveness, rng)
+	defender.hp -= damage
+	if defender.hp < 0 {
+		defender.hp = 0
+	}
+
+	line := fmt.Sprintf("  %s used %s for %d damage", attacker.name, move.name, damage)
+	if critical {
+		line += " (critical hit!)"
+	}
+	if effectiveness > 1 {
+		line += " (super effective!)"
+	} else if effectiveness < 1 {
+		line += " (not very effective)"
+	}
+	return fmt.Sprintf("%s, %s has %d/%d HP left", line, defender.name, defender.hp, defender.maxHP)
+}
+
+// battleDamage rolls the damage of one hit, including same-type attack
+// bonus, type effectiveness, critical hits (1 in 24) and the 85-100%
+// random factor.
+func battleDamage(attacker, defender *battler, move battleMove, effectiveness float64, rng *rand.Rand) (int, bool) {
+	critical := rng.Intn(24) == 0
+	random := 85 + rng.Intn(16)
+	return modifiedDamage(attacker, defender, move, effectiveness, critical, random), critical
+}
+
+// modifiedDamage is the damage of a hit with the critical hit and the
+// random factor (85 to 100) already decided.
+func modifiedDamage(attacker, defender *battler, move battleMove, effectiveness float64, critical bool, random int) int {
+	attack, defense := attacker.stats["attack"], defender.stats["defense"]
+	if move.damageClass == "special" {
+		attack, defense = attacker.stats["special-attack"], defender.stats["special-defense"]
+	}
+
+	damage := float64(baseDamage(attacker.level, move.power, attack, defense))
+	if critical {
+		damage *= 1.5
+	}
+	damage *= float64(random) / 100
+	if hasSTAB(attacker, move) {
+		damage *= 1.5
+	}
+	damage *= effectiveness
+
+	if damage < 1 {
+		damage = 1
+	}
+	return int(damage)
+}
+
+// hasSTAB tells whether a move gets the same-type attack bonus.
+func hasSTAB(attacker *battler, move battleMove) bool {
+	for _, t := range attacker.types {
+		if t == move.typeName {
+			return true
+		}
+	}
+	return false
+}
+
+// baseDamage is the core of the generation 5+ damage formula, before any
+// modifiers are applied.
+func baseDamage(level, power, attack, defense int) int {
+	if defense < 1 {
+		defense = 1
+	}
+	return (2*level/5+2)*power*attack/defense/50 + 2
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func testPokemon(name string, types []string, stats map[string]int) Pokemon {
+	var p Pokemon
+	p.Name = name
+	for _, t := range types {
+		var entry struct {
+			Slot int
+			Type struct {
+				Name string
+				URL  string
+			}
+		}
+		entry.Type.Name = t
+		p.Types = append(p.Types, entry)
+	}
+	for _, statName := range []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"} {
+		var entry struct {
+			BaseStat int
+			Effort   int
+			Stat     struct {
+				Name string
+				URL  string
+			}
+		}
+		entry.BaseStat = stats[statName]
+		entry.Stat.Name = statName
+		p.Stats = append(p.Stats, entry)
+	}
+	return p
+}
+
+func TestBaseDamage(t *testing.T) {
+	tests := []struct {
+		level, power, attack, defense int
+		expected                      int
+	}{
+		{level: 50, power: 90, attack: 100, defense: 100, expected: 41},
+		{level: 100, power: 40, attack: 50, defense: 200, expected: 10},
+		{level: 5, power: 0, attack: 10, defense: 10, expected: 2},
+	}
+
+	for _, test := range tests {
+		actual := baseDamage(test.level, test.power, test.attack, test.defense)
+		if actual != test.expected {
+			t.Errorf("baseDamage(%d, %d, %d, %d) - Expected %d, got %d",
+				test.level, test.power, test.attack, test.defense, test.expected, actual)
+		}
+	}
+}
+
+func TestNewBattlerStats(t *testing.T) {
+	p := testPokemon("pikachu", []string{"electric"}, map[string]int{
+		"hp": 35, "attack": 55, "defense": 40, "special-attack": 50, "special-defense": 50, "speed": 90,
+	})
+	b := newBattler(p, 50, nil)
+
+	if b.maxHP != 95 || b.hp != 95 {
+		t.Errorf("Expected 95 HP, got %d/%d", b.hp, b.maxHP)
+	}
+	if b.stats["speed"] != 95 {
+		t.Errorf("Expected speed 95, got %d", b.stats["speed"])
+	}
+	if !reflect.DeepEqual(b.moves, []battleMove{struggle}) {
+		t.Errorf("Expected a Pokemon without moves to use struggle, got %v", b.moves)
+	}
+}
+
+func TestSimulateBattleIsDeterministic(t *testing.T) {
+	thunderbolt := battleMove{name: "thunderbolt", typeName: "electric", damageClass: "special", power: 90, accuracy: 100}
+	rockThrow := battleMove{name: "rock-throw", typeName: "rock", damageClass: "physical", power: 50, accuracy: 90}
+	stats := map[string]int{"hp": 50, "attack": 60, "defense": 60, "special-attack": 60, "special-defense": 60, "speed": 60}
+
+	run := func(seed int64) (string, []string) {
+		a := newBattler(testPokemon("pikachu", []string{"electric"}, stats), 50, []battleMove{thunderbolt})
+		b := newBattler(testPokemon("geodude", []string{"rock"}, stats), 50, []battleMove{rockThrow})
+		winner, log := simulateBattle(a, b, nil, rand.New(rand.NewSource(seed)))
+		if winner == nil {
+			return "", log
+		}
+		return winner.name, log
+	}
+
+	winner1, log1 := run(42)
+	winner2, log2 := run(42)
+	if winner1 != winner2 || !reflect.DeepEqual(log1, log2) {
+		t.Errorf("Expected the same seed to replay the same battle:\n%v\n%v", log1, log2)
+	}
+	if winner1 == "" {
+		t.Error("Expected the battle to have a winner")
+	}
+}
+
+func TestSimulateBattleFasterPokemonMovesFirst(t *testing.T) {
+	tackle := battleMove{name: "tackle", typeName: "normal", damageClass: "physical", power: 40}
+	slow := testPokemon("slowpoke", []string{"water"}, map[string]int{"hp": 90, "speed": 15, "attack": 65, "defense": 65})
+	fast := testPokemon("jolteon", []string{"electric"}, map[string]int{"hp": 65, "speed": 130, "attack": 65, "defense": 60})
+
+	a := newBattler(slow, 50, []battleMove{tackle})
+	b := newBattler(fast, 50, []battleMove{tackle})
+	_, log := simulateBattle(a, b, nil, rand.New(rand.NewSource(1)))
+
+	if len(log) < 2 || log[1][:9] != "  jolteon" {
+		t.Errorf("Expected jolteon to move first, got log %v", log)
+	}
+}
+
+func TestSimulateBattleImmunity(t *testing.T) {
+	tackle := battleMove{name: "tackle", typeName: "normal", damageClass: "physical", power: 40}
+	shadowBall := battleMove{name: "shadow-ball", typeName: "ghost", damageClass: "special", power: 80}
+	stats := map[string]int{"hp": 60, "attack": 60, "defense": 60, "special-attack": 60, "special-defense": 60, "speed": 60}
+	chart := typeChart{
+		"normal": {"ghost": 0, "fighting": 2},
+		"ghost":  {"normal": 0, "ghost": 2},
+	}
+
+	a := newBattler(testPokemon("snorlax", []string{"normal"}, stats), 50, []battleMove{tackle})
+	b := newBattler(testPokemon("gengar", []string{"ghost"}, stats), 50, []battleMove{shadowBall})
+	winner, log := simulateBattle(a, b, chart, rand.New(rand.NewSource(7)))
+
+	if winner != nil || len(log) == 0 {
+		t.Errorf("Expected a draw between Pokemon immune to each other's moves, got winner %v", winner)
+	}
+	if a.hp != a.maxHP || b.hp != b.maxHP {
+		t.Errorf("Expected no damage, got %d/%d and %d/%d", a.hp, a.maxHP, b.hp, b.maxHP)
+	}
+}
@@ -1,12 +1,12 @@
 package main
 
 import (
-	"encoding/json"
 	"fmt"
 	"io"
 	"math/rand"
-	"net/http"
 	"os"
+	"sort"
+	"strings"
 
 	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
 )
@@ -15,15 +15,90 @@ type config struct {
 	NextURL     *string
 	PreviousURL *string
 	Cache       *pokecache.Cache
-	Pokedex     map[string]Pokemon
+	// Pokedex holds the species data of every species caught so far, and
+	// Seen every species encountered, caught or not.
+	Pokedex map[string]Pokemon
+	Seen    map[string]bool
+	// Box holds every caught Pokemon, in the order they were caught.
+	Box         []*OwnedPokemon
+	NextOwnedID int
+	// Party lists the IDs of up to six Pokemon from the box that make up
+	// the active team, in slot order.
+	Party []int
+	// Inventory counts the items in the bag, keyed by item name.
+	Inventory map[string]int
+	// RegionMap is set while map pages through the areas of one region
+	// instead of all of them.
+	RegionMap *regionMap
+	// Location is the location area explored last.
+	Location string
+	// Rand is the source of all randomness in the game, seeded with Seed.
+	Rand *rand.Rand
+	Seed int64
+	// SessionLog records the seed and commands of this session.
+	SessionLog io.Writer
+	// Wild is the wild Pokemon met with encounter, if it hasn't been
+	// caught yet.
+	Wild     *wildPokemon
+	SavePath string
+	Settings *settings
+	// RunningScript is set while a script started by run is executing.
+	RunningScript bool
+	// PipeInput holds the resources emitted by the previous command in a
+	// pipeline. It is nil when the command is not reading from a pipe.
+	PipeInput []string
+	// PipeOutput collects emitted resources when the command's output is
+	// piped into another command, and is nil otherwise.
+	PipeOutput *[]string
+	// Flags holds the --options given to the running command, keyed by
+	// name without the dashes. Boolean flags are set to "true".
+	Flags map[string]string
+	// TypeChart holds the damage relations of every type looked up so far.
+	TypeChart typeChart
+	// GrowthRates holds the experience table of every growth rate looked
+	// up so far, keyed by growth rate name.
+	GrowthRates map[string][]int
+	// Natures holds the stat changes of every nature looked up so far.
+	Natures map[string]natureModifiers
+	// LocalNames holds names in the chosen language looked up so far,
+	// keyed by kind and identifier, e.g. "pokemon/pikachu".
+	LocalNames map[string]string
 }
 
 type cliCommand struct {
 	name        string
 	description string
+	// usage overrides the usage line generated from args, for commands
+	// whose syntax can't be described by a list of arguments.
+	usage    string
+	args     []argSpec
+	flags    []flagSpec
+	examples []string
+	category string
+	// consumesInput commands read piped resources from config.PipeInput.
+	// Other commands are run once per piped resource instead.
+	consumesInput bool
+	// passthrough commands get their words as they were typed, --options
+	// included, because they hand them on to other commands or scripts.
+	passthrough bool
 	callback    func(*config, []string) error
 }
 
+type argSpec struct {
+	name        string
+	description string
+	optional    bool
+	// variadic arguments take every remaining word and must come last.
+	variadic bool
+}
+
+type flagSpec struct {
+	name        string
+	description string
+	// value names the flag's value in help; boolean flags leave it empty.
+	value string
+}
+
 type locationAreaResponse struct {
 	Count    int     `json:"count"`
 	Next     *string `json:"next"`
@@ -35,10 +110,33 @@ type locationAreaResponse struct {
 }
 
 type locationAreaDetailResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Location struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"location"`
 	PokemonEncounters []struct {
 		Pokemon struct {
 			Name string `json:"name"`
+			URL  string `json:"url"`
 		} `json:"pokemon"`
+		VersionDetails []struct {
+			MaxChance int `json:"max_chance"`
+			Version   struct {
+				Name string `json:"name"`
+				URL  string `json:"url"`
+			} `json:"version"`
+			EncounterDetails []struct {
+				MinLevel int `json:"min_level"`
+				MaxLevel int `json:"max_level"`
+				Chance   int `json:"chance"`
+				Method   struct {
+					Name string `json:"name"`
+					URL  string `json:"url"`
+				} `json:"method"`
+			} `json:"encounter_details"`
+		} `json:"version_details"`
 	} `json:"pokemon_encounters"`
 }
 
@@ -103,7 +201,10 @@ type pokemonDetailResponse struct {
 		Name string `json:"name"`
 		URL  string `json:"url"`
 	} `json:"species"`
-	Forms                  []interface{} `json:"forms"`
+	Forms []struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"forms"`
 	GameIndices            []interface{} `json:"game_indices"`
 	HeldItems              []interface{} `json:"held_items"`
 	LocationAreaEncounters string        `json:"location_area_encounters"`
@@ -171,54 +272,416 @@ type Pokemon struct {
 		Name string
 		URL  string
 	}
-	Forms                  []interface{}
+	Forms []struct {
+		Name string
+		URL  string
+	}
 	GameIndices            []interface{}
 	HeldItems              []interface{}
 	LocationAreaEncounters string
 	IsDefault              bool
 }
 
-var commands = map[string]cliCommand{
-	"exit": {
-		name:        "exit",
-		description: "Exit the Pokedex",
-		callback:    commandExit,
-	},
-	"help": {
-		name:        "help",
-		description: "Show the help menu",
-		callback:    commandHelp,
-	},
-	"map": {
-		name:        "map",
-		description: "Get the next page of locations",
-		callback:    commandMap,
-	},
-	"mapb": {
-		name:        "mapb",
-		description: "Get the previous page of locations",
-		callback:    commandMapb,
-	},
-	"explore": {
-		name:        "explore",
-		description: "Explore a location",
-		callback:    commandExplore,
-	},
-	"catch": {
-		name:        "catch",
-		description: "Catch a Pokemon",
-		callback:    commandCatch,
-	},
-	"inspect": {
-		name:        "inspect",
-		description: "Inspect a Pokemon",
-		callback:    commandInspect,
-	},
-	"pokedex": {
-		name:        "pokedex",
-		description: "Show the Pokedex",
-		callback:    commandPokedex,
-	},
+var commands map[string]cliCommand
+
+// The command table is built in init because commands such as help and
+// alias need to look at it, which would otherwise be an initialization
+// cycle.
+func init() {
+	commands = map[string]cliCommand{
+		"exit": {
+			name:        "exit",
+			description: "Exit the Pokedex",
+			category:    categoryGeneral,
+			callback:    commandExit,
+		},
+		"help": {
+			name:        "help",
+			description: "Show the help menu, or detailed help for one command",
+			args: []argSpec{
+				{name: "command", description: "Command to show detailed help for", optional: true},
+			},
+			examples: []string{"help", "help catch"},
+			category: categoryGeneral,
+			callback: commandHelp,
+		},
+		"lang": {
+			name:        "lang",
+			description: "Show or set the language names are shown in",
+			args: []argSpec{
+				{name: "language", description: "PokeAPI language code such as de, fr or ja-Hrkt, or none", optional: true},
+			},
+			examples: []string{"lang", "lang de", "lang none"},
+			category: categoryGeneral,
+			callback: commandLang,
+		},
+		"mode": {
+			name:        "mode",
+			description: "Show or set the game mode: free catches anything, encounter only what lives where you are",
+			args: []argSpec{
+				{name: "mode", description: "free or encounter", optional: true},
+			},
+			examples: []string{"mode", "mode encounter"},
+			category: categoryGeneral,
+			callback: commandMode,
+		},
+		"location": {
+			name:        "location",
+			description: "Show where you are and which Pokemon live there",
+			category:    categoryExploration,
+			callback:    commandLocation,
+		},
+		"encounter": {
+			name:        "encounter",
+			description: "Look for a wild Pokemon in the location area explored last",
+			args: []argSpec{
+				{name: "method", description: "How to look: walk (default), surf, old-rod, good-rod, super-rod, ...", optional: true},
+			},
+			examples: []string{"encounter", "encounter surf", "encounter | catch"},
+			category: categoryExploration,
+			callback: commandEncounter,
+		},
+		"map": {
+			name:        "map",
+			description: "Get the next page of locations",
+			flags: []flagSpec{
+				{name: "region", value: "region", description: "Only show areas in a region from now on, or all regions again with all"},
+			},
+			examples: []string{"map", "map --region sinnoh", "map --region all"},
+			category: categoryExploration,
+			callback: commandMap,
+		},
+		"mapb": {
+			name:        "mapb",
+			description: "Get the previous page of locations",
+			category:    categoryExploration,
+			callback:    commandMapb,
+		},
+		"regions": {
+			name:        "regions",
+			description: "List the regions of the Pokemon world",
+			examples:    []string{"regions", "regions | locations"},
+			category:    categoryExploration,
+			callback:    commandRegions,
+		},
+		"locations": {
+			name:        "locations",
+			description: "List the locations in a region",
+			args: []argSpec{
+				{name: "region", description: "Region, as listed by regions"},
+			},
+			examples: []string{"locations sinnoh", "locations kanto | areas"},
+			category: categoryExploration,
+			callback: commandLocations,
+		},
+		"areas": {
+			name:        "areas",
+			description: "List the explorable areas of a location",
+			args: []argSpec{
+				{name: "location", description: "Location, as listed by locations"},
+			},
+			examples: []string{"areas eterna-forest", "areas mt-coronet | explore"},
+			category: categoryExploration,
+			callback: commandAreas,
+		},
+		"explore": {
+			name:        "explore",
+			description: "Explore a location and see Pokemon",
+			args: []argSpec{
+				{name: "location-name", description: "Location area to explore, as listed by map"},
+			},
+			examples: []string{"explore canalave-city-area"},
+			category: categoryExploration,
+			callback: commandExplore,
+		},
+		"catch": {
+			name:        "catch",
+			description: "Attempt to catch a Pokemon",
+			args: []argSpec{
+				{name: "pokemon-name", description: "Pokemon to throw a Poke Ball at, defaults to the wild Pokemon found with encounter", optional: true},
+			},
+			flags: []flagSpec{
+				{name: "ball", value: "ball", description: "Ball to throw: poke, great, ultra or master (default poke)"},
+				{name: "method", value: "method", description: "In encounter mode, how to look for the Pokemon, e.g. walk, surf or old-rod"},
+			},
+			examples: []string{"catch pikachu", "catch mewtwo --ball ultra", "catch tentacool --method surf", "encounter | catch --ball great"},
+			category: categoryPokemon,
+			callback: commandCatch,
+		},
+		"inspect": {
+			name:        "inspect",
+			description: "Inspect a Pokemon you have caught",
+			args: []argSpec{
+				{name: "pokemon", description: "ID, nickname or species of one of your Pokemon"},
+			},
+			flags: []flagSpec{
+				{name: "sprite", description: "Draw the Pokemon's sprite"},
+				{name: "color", value: "mode", description: "Sprite colors: truecolor, 256 or ascii (default: detected)"},
+				{name: "full", description: "Also show the species' Pokedex data"},
+			},
+			examples: []string{"inspect pikachu", "inspect #3", "inspect Sparky --sprite --full"},
+			category: categoryPokemon,
+			callback: commandInspect,
+		},
+		"pokedex": {
+			name:        "pokedex",
+			description: "Show the species you have seen and caught",
+			category:    categoryPokemon,
+			callback:    commandPokedex,
+		},
+		"battle": {
+			name:        "battle",
+			description: "Simulate a battle between one of your Pokemon and any other Pokemon",
+			args: []argSpec{
+				{name: "mine", description: "ID, nickname or species of one of your Pokemon, defaults to the first Pokemon in your party", optional: true},
+				{name: "opponent", description: "Pokemon to fight against"},
+			},
+			flags: []flagSpec{
+				{name: "seed", value: "n", description: "Seed for the battle's random numbers, to replay a battle"},
+				{name: "level", value: "n", description: "Level of the opponent, defaults to the level of your Pokemon"},
+			},
+			examples: []string{"battle pikachu geodude", "battle geodude --level 10", "battle #3 geodude --seed 42"},
+			category: categoryBattle,
+			callback: commandBattle,
+		},
+		"damage": {
+			name:        "damage",
+			description: "Calculate the damage range of a move",
+			args: []argSpec{
+				{name: "attacker", description: "One of your Pokemon, or any Pokemon"},
+				{name: "move", description: "Move used by the attacker"},
+				{name: "defender", description: "One of your Pokemon, or any Pokemon"},
+			},
+			flags: []flagSpec{
+				{name: "level", value: "level", description: "Level of Pokemon that aren't yours (default 50)"},
+			},
+			examples: []string{"damage pikachu thunderbolt gyarados", "damage #3 earthquake onix --level 30"},
+			category: categoryBattle,
+			callback: commandDamage,
+		},
+		"matchup": {
+			name:        "matchup",
+			description: "Show a Pokemon's type weaknesses, resistances and immunities, or how two Pokemon match up",
+			usage:       "matchup <pokemon> [vs <pokemon>]",
+			args: []argSpec{
+				{name: "pokemon", description: "Pokemon to show type matchups for"},
+				{name: "vs", description: "The word vs, followed by the opposing Pokemon", optional: true},
+				{name: "opponent", description: "Pokemon to compare against", optional: true},
+			},
+			examples: []string{"matchup charizard", "matchup charizard vs blastoise"},
+			category: categoryBattle,
+			callback: commandMatchup,
+		},
+		"box": {
+			name:        "box",
+			description: "List every Pokemon you have caught",
+			category:    categoryPokemon,
+			callback:    commandBox,
+		},
+		"nickname": {
+			name:        "nickname",
+			description: "Give one of your Pokemon a nickname, or remove it",
+			args: []argSpec{
+				{name: "pokemon", description: "ID, nickname or species of one of your Pokemon"},
+				{name: "nickname", description: "New nickname, leave out to remove it", optional: true},
+			},
+			examples: []string{`nickname pikachu "Sir Sparks"`, "nickname #3 Rocky", "nickname #3"},
+			category: categoryPokemon,
+			callback: commandNickname,
+		},
+		"species": {
+			name:        "species",
+			description: "Show a species' Pokedex data: genus, entries, habitat and more",
+			args: []argSpec{
+				{name: "pokemon", description: "Name of the Pokemon or species"},
+			},
+			flags: []flagSpec{
+				{name: "game", value: "version", description: "Only show the Pokedex entry of one game, e.g. red"},
+			},
+			examples: []string{"species pikachu", "species mewtwo --game red"},
+			category: categoryPokemon,
+			callback: commandSpecies,
+		},
+		"ability": {
+			name:        "ability",
+			description: "Show what an ability does and which Pokemon have it",
+			args: []argSpec{
+				{name: "ability", description: "Name of the ability"},
+			},
+			examples: []string{"ability static", "ability \"swift swim\" | filter caught=no"},
+			category: categoryPokemon,
+			callback: commandAbility,
+		},
+		"moves": {
+			name:        "moves",
+			description: "Show the moves a Pokemon learns in a game",
+			args: []argSpec{
+				{name: "pokemon", description: "Name of the Pokemon"},
+			},
+			flags: []flagSpec{
+				{name: "game", value: "version-group", description: "Game to show, e.g. red-blue (default: the latest)"},
+				{name: "method", value: "method", description: "Only moves learned by level-up, machine, egg or tutor"},
+			},
+			examples: []string{"moves bulbasaur", "moves pikachu --game red-blue --method level-up"},
+			category: categoryPokemon,
+			callback: commandMoves,
+		},
+		"learns": {
+			name:        "learns",
+			description: "List the Pokemon that can learn a move",
+			args: []argSpec{
+				{name: "move", description: "Name of the move"},
+			},
+			examples: []string{"learns thunderbolt", "learns \"swords dance\" | filter caught=yes"},
+			category: categoryPokemon,
+			callback: commandLearns,
+		},
+		"teach": {
+			name:        "teach",
+			description: "Teach one of your Pokemon a move from its learnset",
+			args: []argSpec{
+				{name: "pokemon", description: "ID, nickname or species of one of your Pokemon"},
+				{name: "move", description: "Move to learn"},
+			},
+			flags: []flagSpec{
+				{name: "replace", value: "move", description: "Move to forget when it already knows four"},
+			},
+			examples: []string{"teach pikachu thunderbolt", "teach #2 \"swords dance\" --replace tackle"},
+			category: categoryPokemon,
+			callback: commandTeach,
+		},
+		"forget": {
+			name:        "forget",
+			description: "Make one of your Pokemon forget a move",
+			args: []argSpec{
+				{name: "pokemon", description: "ID, nickname or species of one of your Pokemon"},
+				{name: "move", description: "Move to forget"},
+			},
+			examples: []string{"forget pikachu growl"},
+			category: categoryPokemon,
+			callback: commandForget,
+		},
+		"evolutions": {
+			name:        "evolutions",
+			description: "Show a Pokemon's evolution chain and how each evolution happens",
+			args: []argSpec{
+				{name: "pokemon", description: "Any Pokemon in the chain"},
+			},
+			examples: []string{"evolutions eevee", "evolutions charmander"},
+			category: categoryPokemon,
+			callback: commandEvolutions,
+		},
+		"evolve": {
+			name:        "evolve",
+			description: "Evolve one of your Pokemon if it meets the conditions",
+			args: []argSpec{
+				{name: "pokemon", description: "ID, nickname or species of one of your Pokemon"},
+				{name: "into", description: "Species to evolve into, for Pokemon with several evolutions", optional: true},
+			},
+			flags: []flagSpec{
+				{name: "item", value: "item", description: "Use an evolution item, e.g. water-stone"},
+				{name: "trade", description: "Trade the Pokemon away and back"},
+			},
+			examples: []string{"evolve charmander", "evolve eevee vaporeon --item water-stone", "evolve #4 --trade"},
+			category: categoryPokemon,
+			callback: commandEvolve,
+		},
+		"bag": {
+			name:        "bag",
+			description: "Show the items in your bag",
+			category:    categoryItems,
+			callback:    commandBag,
+		},
+		"party": {
+			name:        "party",
+			description: "Manage your party of up to six Pokemon",
+			usage:       "party [list | add <pokemon> | remove <pokemon|slot> | swap <slot> <slot> | analyze]",
+			args: []argSpec{
+				{name: "action", description: "list, add, remove, swap or analyze (default list)", optional: true},
+				{name: "pokemon", description: "ID, nickname or species of your Pokemon; remove and swap also take 1-based party slots", optional: true, variadic: true},
+			},
+			examples: []string{"party", "party add pikachu", "party add #4", "party swap 1 3", "party remove 2", "party analyze"},
+			category: categoryPokemon,
+			callback: commandParty,
+		},
+		"seed": {
+			name:        "seed",
+			description: "Show or set the seed of the random numbers used for catching, encounters and battles",
+			args: []argSpec{
+				{name: "seed", description: "A number, or random for a new random seed", optional: true},
+			},
+			examples: []string{"seed", "seed 42", "seed random"},
+			category: categoryGeneral,
+			callback: commandSeed,
+		},
+		"alias": {
+			name:        "alias",
+			description: "Create or list command aliases",
+			args: []argSpec{
+				{name: "name", description: "Name of the alias", optional: true},
+				{name: "command", description: "Command the alias expands to", optional: true, variadic: true},
+			},
+			examples:    []string{"alias", "alias e explore", "alias cu catch --ball ultra"},
+			category:    categoryShortcuts,
+			passthrough: true,
+			callback:    commandAlias,
+		},
+		"unalias": {
+			name:        "unalias",
+			description: "Remove a command alias",
+			args: []argSpec{
+				{name: "name", description: "Alias to remove"},
+			},
+			examples: []string{"unalias e"},
+			category: categoryShortcuts,
+			callback: commandUnalias,
+		},
+		"macro": {
+			name:        "macro",
+			description: "Create or list multi-command macros, $1..$9 are replaced by arguments and $@ by all of them; pipes in the body run with the macro",
+			usage:       "macro [<name> = <command>; <command>...]",
+			args: []argSpec{
+				{name: "definition", description: "Macro name, '=' and commands separated by ';'", optional: true, variadic: true},
+			},
+			examples:    []string{"macro", "macro hunt = explore $1; catch $2 --ball great", "macro fire = pokedex | filter type=fire; party analyze"},
+			category:    categoryShortcuts,
+			passthrough: true,
+			callback:    commandMacro,
+		},
+		"unmacro": {
+			name:        "unmacro",
+			description: "Remove a macro",
+			args: []argSpec{
+				{name: "name", description: "Macro to remove"},
+			},
+			examples: []string{"unmacro hunt"},
+			category: categoryShortcuts,
+			callback: commandUnmacro,
+		},
+		"filter": {
+			name:        "filter",
+			description: "Keep only the piped Pokemon that match every condition",
+			args: []argSpec{
+				{name: "condition", description: "name=<text>, type=<type> or caught=<yes|no>, use != to negate", variadic: true},
+			},
+			examples:      []string{"explore mt-coronet-1f | filter type=rock | catch", "pokedex | filter type!=water"},
+			category:      categoryAutomation,
+			consumesInput: true,
+			callback:      commandFilter,
+		},
+		"run": {
+			name:        "run",
+			description: "Run a Starlark script that can call commands and the PokeAPI",
+			args: []argSpec{
+				{name: "script", description: "Path of the script to run"},
+				{name: "args", description: "Arguments available to the script as argv", optional: true, variadic: true},
+			},
+			examples:    []string{"run hunt.star", "run explore-region.star sinnoh --verbose"},
+			category:    categoryAutomation,
+			passthrough: true,
+			callback:    commandRun,
+		},
+	}
 }
 
 func commandExit(cfg *config, args []string) error {
@@ -228,24 +691,26 @@ func commandExit(cfg *config, args []string) error {
 	return nil
 }
 
-func commandHelp(cfg *config, args []string) error {
-	_ = args
-	fmt.Println("Welcome to the Pokedex!")
-	fmt.Println("Usage:")
-	fmt.Println("help: Displays a help message")
-	fmt.Println("exit: Exits the Pokedex")
-	fmt.Println("map: Get the next page of locations")
-	fmt.Println("mapb: Get the previous page of locations")
-	fmt.Println("explore <location-name>: Explore a location and see Pokemon")
-	fmt.Println("catch <pokemon-name>: Attempt to catch a Pokemon")
-	fmt.Println("inspect <pokemon-name>: Inspect a Pokemon")
-	fmt.Println("pokedex: Show the Pokedex")
-	return nil
-}
-
 func commandMap(cfg *config, args []string) error {
 	_ = args
-	url := "https://pokeapi.co/api/v2/location-area/"
+	if value, ok := cfg.flag("region"); ok {
+		region := resourceName(value)
+		if region == "all" {
+			cfg.RegionMap = nil
+			cfg.NextURL = nil
+			cfg.PreviousURL = nil
+		} else {
+			m, err := newRegionMap(cfg, region)
+			if err != nil {
+				return err
+			}
+			cfg.RegionMap = m
+		}
+	}
+	if cfg.RegionMap != nil {
+		return commandRegionMap(cfg)
+	}
+	url := pokeAPIBaseURL + "location-area/"
 	if cfg.NextURL != nil {
 		url = *cfg.NextURL
 	}
@@ -258,15 +723,20 @@ func commandMap(cfg *config, args []string) error {
 	cfg.NextURL = res.Next
 	cfg.PreviousURL = res.Previous
 
-	for _, area := range res.Results {
-		fmt.Println(area.Name)
+	names := make([]string, len(res.Results))
+	for i, area := range res.Results {
+		names[i] = area.Name
 	}
+	cfg.emitLocalized("", "location-area", names)
 
 	return nil
 }
 
 func commandMapb(cfg *config, args []string) error {
 	_ = args
+	if cfg.RegionMap != nil {
+		return commandRegionMapb(cfg)
+	}
 	if cfg.PreviousURL == nil {
 		fmt.Println("You're on the first page")
 		return nil
@@ -280,9 +750,11 @@ func commandMapb(cfg *config, args []string) error {
 	cfg.NextURL = res.Next
 	cfg.PreviousURL = res.Previous
 
-	for _, area := range res.Results {
-		fmt.Println(area.Name)
+	names := make([]string, len(res.Results))
+	for i, area := range res.Results {
+		names[i] = area.Name
 	}
+	cfg.emitLocalized("", "location-area", names)
 
 	return nil
 }
@@ -292,63 +764,154 @@ func commandExplore(cfg *config, args []string) error {
 	if len(args) != 1 {
 		return fmt.Errorf("explore command requires a location name")
 	}
-	location := args[0]
-	url := fmt.Sprintf("https://pokeapi.co/api/v2/location-area/%s", location)
+	location := resourceName(args[0])
+	url := pokeAPIBaseURL + "location-area/" + location
 	res, err := fetchLocationAreaDetail(url, cfg.Cache)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Exploring %s...\n", location)
-	fmt.Println("Found Pokemon:")
-	for _, encounter := range res.PokemonEncounters {
-		fmt.Printf("  - %s\n", encounter.Pokemon.Name)
+	// A wild Pokemon stays behind in the area it was met in.
+	if location != cfg.Location {
+		cfg.Wild = nil
+	}
+	cfg.Location = location
+	fmt.Printf("Exploring %s...\n", cfg.localize("location-area", location))
+	names := make([]string, len(res.PokemonEncounters))
+	for i, encounter := range res.PokemonEncounters {
+		names[i] = encounter.Pokemon.Name
+	}
+	cfg.markSeen(names...)
+	cfg.emitLocalized("Found Pokemon:", "pokemon", names)
+
+	if err := saveGame(cfg); err != nil {
+		return fmt.Errorf("could not save location: %w", err)
 	}
 
 	return nil
 }
 
 func commandCatch(cfg *config, args []string) error {
-	_ = args
-	if len(args) != 1 {
-		return fmt.Errorf("catch command requires a Pokemon name")
+	wild := cfg.Wild
+	var pokemonName string
+	if len(args) == 1 {
+		pokemonName = resourceName(args[0])
+		if cfg.Settings.language() != "" {
+			if slug := cfg.unlocalize("pokemon", args[0], catchCandidates(cfg)); slug != "" {
+				pokemonName = slug
+			}
+		}
+		if wild != nil && wild.name != pokemonName {
+			wild = nil
+		}
+	} else if wild != nil {
+		pokemonName = wild.name
+	} else {
+		return fmt.Errorf("catch command requires a Pokemon name, or a wild Pokemon found with encounter")
+	}
+
+	ball := defaultBall
+	if value, ok := cfg.flag("ball"); ok {
+		ball = ballName(value)
+	}
+	modifier, ok := ballModifiers[ball]
+	if !ok {
+		return fmt.Errorf("%s is not a Poke Ball", ball)
+	}
+	if !hasBall(cfg, ball) {
+		return fmt.Errorf("you don't have any %s left", ball)
 	}
-	pokemonName := args[0]
 
-	url := fmt.Sprintf("https://pokeapi.co/api/v2/pokemon/%s", pokemonName)
-	pokemon, err := fetchPokemon(url, cfg.Cache)
+	pokemon, err := fetchCatchable(cfg, pokemonName)
+	if err != nil {
+		return err
+	}
+	species, err := fetchSpecies(pokemon.Species.URL, cfg.Cache)
+	if err != nil {
+		return err
+	}
+	levels, err := loadGrowthRate(cfg, species.GrowthRate.Name)
+	if err != nil {
+		return err
+	}
+	nature, err := rollNature(cfg)
 	if err != nil {
 		return err
 	}
 
-	fmt.Printf("Throwing a Pokeball at %s...\n", pokemonName)
+	pokemonName = pokemon.Name
+	cfg.markSeen(pokemonName)
+	shownName := cfg.localize("pokemon", pokemonName)
 
-	// Calculate catch chance based on base experience
-	// Higher base experience = harder to catch
-	// Base experience typically ranges from ~50 to ~300+
-	// We'll use: catchThreshold = max(0, 100 - baseExperience/10)
-	// This gives us a range where low exp (50) = 95% chance, high exp (300) = 70% chance
-	catchThreshold := 100 - (pokemon.BaseExperience / 10)
-	if catchThreshold < 0 {
-		catchThreshold = 0
-	}
-	if catchThreshold > 100 {
-		catchThreshold = 100
+	level := defaultCatchLevel
+	if wild != nil {
+		level = wild.level
+	} else if cfg.Settings.gameMode() == gameModeEncounter {
+		area, err := currentArea(cfg)
+		if err != nil {
+			return err
+		}
+		method, _ := cfg.flag("method")
+		slot, err := findEncounterSlot(encounterSlots(area), pokemonName, resourceName(method))
+		if err != nil {
+			return err
+		}
+		if cfg.Rand.Intn(100) >= slot.chance {
+			fmt.Printf("You searched %s (%s) but couldn't find a wild %s\n", cfg.localize("location-area", area.Name), slot.method, shownName)
+			return nil
+		}
+		level = slot.minLevel + cfg.Rand.Intn(slot.maxLevel-slot.minLevel+1)
+		fmt.Printf("A wild %s (Lv. %d) appeared!\n", shownName, level)
 	}
 
-	randomValue := rand.Intn(100)
+	useBall(cfg, ball)
+	fmt.Printf("Throwing a %s at %s...\n", ball, shownName)
 
-	if randomValue < catchThreshold {
-		fmt.Printf("%s was caught!\n", pokemonName)
-		if _, ok := cfg.Pokedex[pokemonName]; ok {
-			fmt.Printf("%s is already in your Pokedex!\n", pokemonName)
-		} else {
-			cfg.Pokedex[pokemonName] = convertToPokemon(pokemon)
+	// The chance depends on the species' capture rate (3 for legendaries
+	// up to 255 for the most common Pokemon) and the ball's modifier.
+	shakes, caught := attemptCatch(species.CaptureRate, modifier, cfg.Rand.Intn)
+	for i := 1; i <= shakes && i <= 3; i++ {
+		fmt.Println(strings.Repeat("...", i) + "shake")
+	}
+
+	if caught {
+		if wild != nil {
+			cfg.Wild = nil
 		}
+		_, known := cfg.Pokedex[pokemonName]
+		owned := addOwned(cfg, convertToPokemon(pokemon), level)
+		owned.GrowthRate = species.GrowthRate.Name
+		owned.Experience = levels[level]
+		owned.IVs = rollIVs(cfg.Rand.Intn)
+		owned.Nature = nature
+		owned.Moves = defaultMoves(cfg.Pokedex[pokemonName], level)
+		owned.Gender = rollGender(species.GenderRate, cfg.Rand.Intn)
+		owned.Shiny = rollShiny(cfg.Rand.Intn)
+		owned.Form = rollForm(cfg.Pokedex[pokemonName], cfg.Rand.Intn)
+		if owned.Shiny {
+			fmt.Printf("Wow, %s is shiny!\n", shownName)
+		}
+		fmt.Printf("%s was caught! It was sent to your box as %s\n", shownName, owned.label(cfg))
+		if !known {
+			fmt.Printf("%s's data was added to your Pokedex!\n", shownName)
+		}
+		// Like in the newer games, catching a Pokemon gives the party
+		// lead experience as if it had been defeated.
+		if members := partyMembers(cfg); len(members) > 0 {
+			lead := members[0]
+			gainEffort(lead, convertToPokemon(pokemon))
+			if err := gainExperience(cfg, lead, experienceYield(pokemon.BaseExperience, level, lead.Level)); err != nil {
+				return err
+			}
+		}
+		cfg.forward(pokemonName)
 	} else {
-		fmt.Printf("%s escaped!\n", pokemonName)
+		fmt.Printf("%s escaped!\n", shownName)
 	}
 
+	if err := saveGame(cfg); err != nil {
+		return fmt.Errorf("could not save Pokedex: %w", err)
+	}
 	return nil
 }
 
@@ -356,33 +919,144 @@ func commandInspect(cfg *config, args []string) error {
 	if len(args) != 1 {
 		return fmt.Errorf("inspect command requires a Pokemon name")
 	}
-	pokemonName := args[0]
-	if _, ok := cfg.Pokedex[pokemonName]; !ok {
-		return fmt.Errorf("you have not caught that pokemon: %s", pokemonName)
+	owned, err := findOwned(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	pokemon := cfg.Pokedex[owned.Species]
+
+	if _, ok := cfg.flag("sprite"); ok {
+		mode, _ := cfg.flag("color")
+		mode = strings.ToLower(mode)
+		switch mode {
+		case "", spriteTrueColor, sprite256, spriteASCII:
+		default:
+			return fmt.Errorf("unknown color mode: %s", mode)
+		}
+		if err := printSprite(cfg, owned, mode); err != nil {
+			return err
+		}
+	}
+	fmt.Printf("ID: #%d\n", owned.ID)
+	if owned.Nickname != "" {
+		fmt.Printf("Nickname: %s\n", owned.Nickname)
+	}
+	fmt.Printf("Name: %s\n", cfg.localize("pokemon", pokemon.Name))
+	if owned.Form != "" {
+		fmt.Printf("Form: %s\n", owned.Form)
+	}
+	if owned.Gender != "" {
+		fmt.Printf("Gender: %s\n", owned.Gender)
+	}
+	if owned.Shiny {
+		fmt.Println("Shiny: yes")
+	}
+	if species, err := fetchSpecies(pokemon.Species.URL, cfg.Cache); err == nil && len(species.Varieties) > 1 {
+		varieties := make([]string, len(species.Varieties))
+		for i, variety := range species.Varieties {
+			varieties[i] = variety.Pokemon.Name
+		}
+		fmt.Printf("Varieties: %s\n", strings.Join(varieties, ", "))
+	}
+	fmt.Printf("Level: %d\n", owned.Level)
+	if levels, err := ownedGrowthRate(cfg, owned); err == nil && owned.Level < maxLevel {
+		fmt.Printf("Experience: %d (%d to Lv. %d, %s)\n", owned.Experience,
+			levels[owned.Level+1]-max(owned.Experience, levels[owned.Level]), owned.Level+1, owned.GrowthRate)
+	} else {
+		fmt.Printf("Experience: %d\n", owned.Experience)
+	}
+	fmt.Printf("Caught: %s", owned.CaughtAt.Format("2006-01-02 15:04"))
+	if owned.Location != "" {
+		fmt.Printf(" at %s", cfg.localize("location-area", owned.Location))
+	}
+	fmt.Println()
+	fmt.Printf("Height: %d\n", pokemon.Height)
+	fmt.Printf("Weight: %d\n", pokemon.Weight)
+	nature, err := loadNature(cfg, owned.Nature)
+	if err != nil {
+		return err
+	}
+	if owned.Nature != "" {
+		fmt.Printf("Nature: %s", owned.Nature)
+		if nature.increased != nature.decreased {
+			fmt.Printf(" (+%s, -%s)", nature.increased, nature.decreased)
+		}
+		fmt.Println()
+	}
+	stats, err := ownedStats(cfg, owned)
+	if err != nil {
+		return err
 	}
-	fmt.Printf("Name: %s\n", cfg.Pokedex[pokemonName].Name)
-	fmt.Printf("Height: %d\n", cfg.Pokedex[pokemonName].Height)
-	fmt.Printf("Weight: %d\n", cfg.Pokedex[pokemonName].Weight)
 	fmt.Printf("Stats:\n")
-	for _, stat := range cfg.Pokedex[pokemonName].Stats {
-		fmt.Printf("  - %s: %d\n", stat.Stat.Name, stat.BaseStat)
+	for _, stat := range pokemon.Stats {
+		name := stat.Stat.Name
+		fmt.Printf("  - %s: %d (base %d, IV %d, EV %d)\n", name, stats[name], stat.BaseStat, owned.IVs[name], owned.EVs[name])
 	}
 	fmt.Printf("Types:\n")
-	for _, t := range cfg.Pokedex[pokemonName].Types {
-		fmt.Printf("  - %s\n", t.Type.Name)
+	for _, t := range pokemon.Types {
+		fmt.Printf("  - %s\n", cfg.localize("type", t.Type.Name))
+	}
+	fmt.Printf("Abilities:\n")
+	for _, a := range pokemon.Abilities {
+		if a.IsHidden {
+			fmt.Printf("  - %s (hidden)\n", cfg.localize("ability", a.Ability.Name))
+		} else {
+			fmt.Printf("  - %s\n", cfg.localize("ability", a.Ability.Name))
+		}
+	}
+	if len(owned.Moves) > 0 {
+		fmt.Printf("Moves:\n")
+		for _, move := range owned.Moves {
+			fmt.Printf("  - %s\n", cfg.localize("move", move))
+		}
+	}
+	if _, ok := cfg.flag("full"); ok {
+		species, err := fetchSpecies(pokemon.Species.URL, cfg.Cache)
+		if err != nil {
+			return err
+		}
+		printSpecies(species, "", cfg.Settings.language())
 	}
 	return nil
 }
 
 func commandPokedex(cfg *config, args []string) error {
 	_ = args
-	fmt.Println("Your Pokedex:")
-	for _, pokemon := range cfg.Pokedex {
-		fmt.Printf("  - %s\n", pokemon.Name)
+	if cfg.PipeOutput != nil {
+		names := make([]string, 0, len(cfg.Pokedex))
+		for name := range cfg.Pokedex {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		cfg.emit("", names)
+		return nil
+	}
+
+	species := sortedSpecies(cfg)
+	fmt.Printf("Your Pokedex: %d seen, %d caught\n", len(species), len(cfg.Pokedex))
+	for _, name := range species {
+		if _, caught := cfg.Pokedex[name]; caught {
+			fmt.Printf("  - %s (caught, %s)\n", cfg.localize("pokemon", name), ownedSummary(cfg, name))
+		} else {
+			fmt.Printf("  - %s (seen)\n", cfg.localize("pokemon", name))
+		}
 	}
 	return nil
 }
 
+// lookupPokemon returns a caught Pokemon from the Pokedex, or fetches it
+// from the PokeAPI if it hasn't been caught.
+func lookupPokemon(cfg *config, name string) (Pokemon, error) {
+	if pokemon, ok := cfg.Pokedex[name]; ok {
+		return pokemon, nil
+	}
+	res, err := fetchPokemon(pokeAPIBaseURL+"pokemon/"+name, cfg.Cache)
+	if err != nil {
+		return Pokemon{}, err
+	}
+	return convertToPokemon(res), nil
+}
+
 func convertToPokemon(p *pokemonDetailResponse) Pokemon {
 	pokemon := Pokemon{
 		ID:                     p.ID,
@@ -393,11 +1067,20 @@ func convertToPokemon(p *pokemonDetailResponse) Pokemon {
 		Order:                  p.Order,
 		LocationAreaEncounters: p.LocationAreaEncounters,
 		IsDefault:              p.IsDefault,
-		Forms:                  p.Forms,
 		GameIndices:            p.GameIndices,
 		HeldItems:              p.HeldItems,
 	}
 
+	// Copy Forms
+	pokemon.Forms = make([]struct {
+		Name string
+		URL  string
+	}, len(p.Forms))
+	for i, form := range p.Forms {
+		pokemon.Forms[i].Name = form.Name
+		pokemon.Forms[i].URL = form.URL
+	}
+
 	// Copy Sprites
 	pokemon.Sprites = struct {
 		BackDefault      string
@@ -587,136 +1270,13 @@ func convertToPokemon(p *pokemonDetailResponse) Pokemon {
 }
 
 func fetchLocationAreas(url string, cache *pokecache.Cache) (*locationAreaResponse, error) {
-	if cachedData, ok := cache.Get(url); ok {
-		var locationRes locationAreaResponse
-		err := json.Unmarshal(cachedData, &locationRes)
-		if err != nil {
-			return nil, err
-		}
-		return &locationRes, nil
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "PokedexCLI")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	cache.Add(url, body)
-
-	var locationRes locationAreaResponse
-	err = json.Unmarshal(body, &locationRes)
-	if err != nil {
-		return nil, err
-	}
-
-	return &locationRes, nil
+	return fetchResource[locationAreaResponse](url, cache)
 }
 
 func fetchLocationAreaDetail(url string, cache *pokecache.Cache) (*locationAreaDetailResponse, error) {
-	if cachedData, ok := cache.Get(url); ok {
-		var locationDetail locationAreaDetailResponse
-		err := json.Unmarshal(cachedData, &locationDetail)
-		if err != nil {
-			return nil, err
-		}
-		return &locationDetail, nil
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "PokedexCLI")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	cache.Add(url, body)
-
-	var locationDetail locationAreaDetailResponse
-	err = json.Unmarshal(body, &locationDetail)
-	if err != nil {
-		return nil, err
-	}
-
-	return &locationDetail, nil
+	return fetchResource[locationAreaDetailResponse](url, cache)
 }
 
 func fetchPokemon(url string, cache *pokecache.Cache) (*pokemonDetailResponse, error) {
-	if cachedData, ok := cache.Get(url); ok {
-		var pokemon pokemonDetailResponse
-		err := json.Unmarshal(cachedData, &pokemon)
-		if err != nil {
-			return nil, err
-		}
-		return &pokemon, nil
-	}
-
-	req, err := http.NewRequest("GET", url, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	req.Header.Set("Content-Type", "application/json")
-	req.Header.Set("User-Agent", "PokedexCLI")
-
-	client := &http.Client{}
-	resp, err := client.Do(req)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
-	}
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	cache.Add(url, body)
-
-	var pokemon pokemonDetailResponse
-	err = json.Unmarshal(body, &pokemon)
-	if err != nil {
-		return nil, err
-	}
-
-	return &pokemon, nil
+	return fetchResource[pokemonDetailResponse](url, cache)
 }
@@ -0,0 +1,110 @@
+package main
+
+import (
+	"fmt"
+	"strconv"
+)
+
+// defaultCalcLevel is the level of Pokemon in the damage calculator that
+// aren't one of the trainer's, as in competitive play.
+const defaultCalcLevel = 50
+
+// damageRange is the lowest and highest damage of a hit, without and with
+// a critical hit.
+type damageRange struct {
+	min, max                 int
+	criticalMin, criticalMax int
+}
+
+func calculateDamageRange(attacker, defender *battler, move battleMove, effectiveness float64) damageRange {
+	return damageRange{
+		min:         modifiedDamage(attacker, defender, move, effectiveness, false, 85),
+		max:         modifiedDamage(attacker, defender, move, effectiveness, false, 100),
+		criticalMin: modifiedDamage(attacker, defender, move, effectiveness, true, 85),
+		criticalMax: modifiedDamage(attacker, defender, move, effectiveness, true, 100),
+	}
+}
+
+// calcBattler prepares one side of a damage calculation. One of the
+// trainer's Pokemon is used with its actual stats; any other Pokemon has
+// the stats of a wild one at level.
+func calcBattler(cfg *config, ref string, level int) (*battler, error) {
+	if owned, err := findOwned(cfg, ref); err == nil {
+		return newOwnedBattler(cfg, owned, nil)
+	}
+	pokemon, err := lookupPokemon(cfg, resourceName(ref))
+	if err != nil {
+		return nil, err
+	}
+	b := newBattler(pokemon, level, nil)
+	b.name = cfg.localize("pokemon", pokemon.Name)
+	return b, nil
+}
+
+func commandDamage(cfg *config, args []string) error {
+	level := defaultCalcLevel
+	if value, ok := cfg.flag("level"); ok {
+		var err error
+		level, err = strconv.Atoi(value)
+		if err != nil || level < 1 || level > maxLevel {
+			return fmt.Errorf("invalid level: %s", value)
+		}
+	}
+
+	attacker, err := calcBattler(cfg, args[0], level)
+	if err != nil {
+		return err
+	}
+	defender, err := calcBattler(cfg, args[2], level)
+	if err != nil {
+		return err
+	}
+	res, err := fetchMove(pokeAPIBaseURL+"move/"+resourceName(args[1]), cfg.Cache)
+	if err != nil {
+		return err
+	}
+	move := toBattleMove(cfg, res)
+	if move.power == 0 {
+		return fmt.Errorf("%s has no base power, so its damage can't be calculated", move.name)
+	}
+	if err := loadTypeChart(cfg, append(defender.types, move.typeName)...); err != nil {
+		return err
+	}
+
+	fmt.Printf("%s (Lv. %d) using %s on %s (Lv. %d, %d HP):\n",
+		attacker.name, attacker.level, move.name, defender.name, defender.level, defender.maxHP)
+	effectiveness := cfg.TypeChart.multiplier(move.typeName, defender.types)
+	if effectiveness == 0 {
+		fmt.Printf("  It doesn't affect %s...\n", defender.name)
+		return nil
+	}
+
+	r := calculateDamageRange(attacker, defender, move, effectiveness)
+	fmt.Printf("  Damage: %d-%d (%s)\n", r.min, r.max, hpPercentRange(r.min, r.max, defender.maxHP))
+	fmt.Printf("  Critical hit: %d-%d (%s)\n", r.criticalMin, r.criticalMax, hpPercentRange(r.criticalMin, r.criticalMax, defender.maxHP))
+	modifiers := fmt.Sprintf("%sx effective", formatMultiplier(effectiveness))
+	if hasSTAB(attacker, move) {
+		modifiers += ", with STAB"
+	}
+	fmt.Printf("  %s\n", modifiers)
+	fmt.Printf("  %s\n", hitsToFaint(r, defender))
+	return nil
+}
+
+func hpPercentRange(min, max, hp int) string {
+	return fmt.Sprintf("%.1f%%-%.1f%% of HP", float64(min)*100/float64(hp), float64(max)*100/float64(hp))
+}
+
+// hitsToFaint describes how many hits without critical hits it takes to
+// make the defender faint from full HP.
+func hitsToFaint(r damageRange, defender *battler) string {
+	most := (defender.maxHP + r.min - 1) / r.min
+	fewest := (defender.maxHP + r.max - 1) / r.max
+	switch {
+	case most == 1:
+		return "Always faints in one hit"
+	case fewest == most:
+		return fmt.Sprintf("Always faints in %d hits", most)
+	}
+	return fmt.Sprintf("Faints in %d to %d hits", fewest, most)
+}
@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCalculateDamageRange(t *testing.T) {
+	attacker := &battler{name: "pikachu", level: 50, types: []string{"electric"}, stats: map[string]int{"special-attack": 100}}
+	defender := &battler{name: "gyarados", level: 50, maxHP: 150, stats: map[string]int{"special-defense": 100}}
+	thunderbolt := battleMove{name: "thunderbolt", typeName: "electric", damageClass: "special", power: 90}
+
+	r := calculateDamageRange(attacker, defender, thunderbolt, 2)
+	expected := damageRange{min: 104, max: 123, criticalMin: 156, criticalMax: 184}
+	if r != expected {
+		t.Errorf("Expected %+v, got %+v", expected, r)
+	}
+	if actual := hitsToFaint(r, defender); actual != "Always faints in 2 hits" {
+		t.Errorf("Expected 2 hits to faint, got %q", actual)
+	}
+
+	attacker.types = []string{"normal"}
+	r = calculateDamageRange(attacker, defender, thunderbolt, 0.5)
+	if r.min != 17 || r.max != 20 {
+		t.Errorf("Expected 17-20 without STAB and resisted, got %d-%d", r.min, r.max)
+	}
+	if actual := hitsToFaint(r, defender); actual != "Faints in 8 to 9 hits" {
+		t.Errorf("Expected 8 to 9 hits to faint, got %q", actual)
+	}
+}
@@ -0,0 +1,255 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// In free mode any Pokemon can be caught anywhere. In encounter mode
+	// catch only works for Pokemon that live in the location area explored
+	// last, and only as often as they show up there.
+	gameModeFree      = "free"
+	gameModeEncounter = "encounter"
+)
+
+// encounterSlot describes how often and at which levels a Pokemon is met
+// in a location area with one encounter method, such as walk or surf.
+type encounterSlot struct {
+	pokemon  string
+	method   string
+	chance   int
+	minLevel int
+	maxLevel int
+}
+
+// encounterSlots summarizes an area's encounter table. Every game version
+// has its own table; for each Pokemon and method the version where it is
+// most common is used.
+func encounterSlots(area *locationAreaDetailResponse) []encounterSlot {
+	var slots []encounterSlot
+	for _, encounter := range area.PokemonEncounters {
+		best := make(map[string]encounterSlot)
+		for _, version := range encounter.VersionDetails {
+			perMethod := make(map[string]encounterSlot)
+			for _, detail := range version.EncounterDetails {
+				slot, ok := perMethod[detail.Method.Name]
+				if !ok {
+					slot = encounterSlot{
+						pokemon:  encounter.Pokemon.Name,
+						method:   detail.Method.Name,
+						minLevel: detail.MinLevel,
+						maxLevel: detail.MaxLevel,
+					}
+				}
+				slot.chance += detail.Chance
+				slot.minLevel = min(slot.minLevel, detail.MinLevel)
+				slot.maxLevel = max(slot.maxLevel, detail.MaxLevel)
+				perMethod[detail.Method.Name] = slot
+			}
+			for method, slot := range perMethod {
+				if slot.chance > best[method].chance {
+					best[method] = slot
+				}
+			}
+		}
+
+		methods := make([]string, 0, len(best))
+		for method := range best {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			slot := best[method]
+			slot.chance = min(slot.chance, 100)
+			slots = append(slots, slot)
+		}
+	}
+	return slots
+}
+
+// findEncounterSlot looks up how a Pokemon can be met. With an empty
+// method the most likely way to meet it is used.
+func findEncounterSlot(slots []encounterSlot, pokemon, method string) (encounterSlot, error) {
+	var found []encounterSlot
+	for _, slot := range slots {
+		if slot.pokemon == pokemon {
+			found = append(found, slot)
+		}
+	}
+	if len(found) == 0 {
+		return encounterSlot{}, fmt.Errorf("no wild %s lives here", pokemon)
+	}
+
+	var best encounterSlot
+	var methods []string
+	for _, slot := range found {
+		if slot.method == method {
+			return slot, nil
+		}
+		if slot.chance > best.chance {
+			best = slot
+		}
+		methods = append(methods, slot.method)
+	}
+	if method != "" {
+		return encounterSlot{}, fmt.Errorf("%s can't be found here with %s, try %s", pokemon, method, strings.Join(methods, " or "))
+	}
+	return best, nil
+}
+
+// currentArea fetches the location area explored last.
+func currentArea(cfg *config) (*locationAreaDetailResponse, error) {
+	if cfg.Location == "" {
+		return nil, fmt.Errorf("you haven't explored anywhere yet, use explore <location-name>")
+	}
+	return fetchLocationAreaDetail(pokeAPIBaseURL+"location-area/"+cfg.Location, cfg.Cache)
+}
+
+// catchCandidates are the Pokemon catch input most likely refers to: the
+// wild Pokemon and those living in the current area.
+func catchCandidates(cfg *config) []string {
+	var candidates []string
+	if cfg.Wild != nil {
+		candidates = append(candidates, cfg.Wild.name)
+	}
+	if cfg.Location == "" {
+		return candidates
+	}
+	area, err := currentArea(cfg)
+	if err != nil {
+		return candidates
+	}
+	for _, encounter := range area.PokemonEncounters {
+		candidates = append(candidates, encounter.Pokemon.Name)
+	}
+	return candidates
+}
+
+func commandLocation(cfg *config, args []string) error {
+	_ = args
+	fmt.Printf("Game mode: %s\n", cfg.Settings.gameMode())
+	if cfg.Location == "" {
+		fmt.Println("You haven't explored anywhere yet")
+		return nil
+	}
+
+	area, err := currentArea(cfg)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("You are in %s", cfg.localize("location-area", area.Name))
+	if area.Location.Name != "" {
+		fmt.Printf(" (%s)", cfg.localize("location", area.Location.Name))
+	}
+	fmt.Println()
+
+	byMethod := make(map[string][]string)
+	for _, slot := range encounterSlots(area) {
+		entry := fmt.Sprintf("%s %d%% Lv. %d-%d", cfg.localize("pokemon", slot.pokemon), slot.chance, slot.minLevel, slot.maxLevel)
+		byMethod[slot.method] = append(byMethod[slot.method], entry)
+	}
+	methods := make([]string, 0, len(byMethod))
+	for method := range byMethod {
+		methods = append(methods, method)
+	}
+	sort.Strings(methods)
+	for _, method := range methods {
+		fmt.Printf("%s:\n", method)
+		for _, entry := range byMethod[method] {
+			fmt.Printf("  - %s\n", entry)
+		}
+	}
+	return nil
+}
+
+func commandMode(cfg *config, args []string) error {
+	if len(args) == 0 {
+		fmt.Printf("Game mode: %s\n", cfg.Settings.gameMode())
+		return nil
+	}
+
+	mode := strings.ToLower(args[0])
+	if mode != gameModeFree && mode != gameModeEncounter {
+		return fmt.Errorf("unknown game mode %q, expected %s or %s", mode, gameModeFree, gameModeEncounter)
+	}
+	cfg.Settings.GameMode = mode
+	if err := cfg.Settings.save(); err != nil {
+		return fmt.Errorf("could not save game mode: %w", err)
+	}
+	fmt.Printf("Game mode set to %s\n", mode)
+	return nil
+}
+
+// defaultEncounterMethod is used by encounter when no method is given.
+const defaultEncounterMethod = "walk"
+
+// wildPokemon is a Pokemon met with encounter, waiting to be caught.
+type wildPokemon struct {
+	name   string
+	level  int
+	method string
+}
+
+// rollEncounter picks a wild Pokemon among the slots of one method,
+// weighted by their chances, and rolls its level. intn is the source of
+// randomness, e.g. cfg.Rand.Intn.
+func rollEncounter(slots []encounterSlot, method string, intn func(int) int) (*wildPokemon, error) {
+	var candidates []encounterSlot
+	total := 0
+	methods := make(map[string]bool)
+	for _, slot := range slots {
+		methods[slot.method] = true
+		if slot.method == method && slot.chance > 0 {
+			candidates = append(candidates, slot)
+			total += slot.chance
+		}
+	}
+	if len(candidates) == 0 {
+		available := make([]string, 0, len(methods))
+		for m := range methods {
+			available = append(available, m)
+		}
+		sort.Strings(available)
+		if len(available) == 0 {
+			return nil, fmt.Errorf("no wild Pokemon live here")
+		}
+		return nil, fmt.Errorf("no Pokemon can be found here with %s, try %s", method, strings.Join(available, " or "))
+	}
+
+	roll := intn(total)
+	for _, slot := range candidates {
+		if roll < slot.chance {
+			return &wildPokemon{
+				name:   slot.pokemon,
+				level:  slot.minLevel + intn(slot.maxLevel-slot.minLevel+1),
+				method: method,
+			}, nil
+		}
+		roll -= slot.chance
+	}
+	return nil, fmt.Errorf("no wild Pokemon found")
+}
+
+func commandEncounter(cfg *config, args []string) error {
+	method := defaultEncounterMethod
+	if len(args) == 1 {
+		method = resourceName(args[0])
+	}
+
+	area, err := currentArea(cfg)
+	if err != nil {
+		return err
+	}
+	wild, err := rollEncounter(encounterSlots(area), method, cfg.Rand.Intn)
+	if err != nil {
+		return err
+	}
+
+	cfg.Wild = wild
+	cfg.markSeen(wild.name)
+	fmt.Printf("A wild %s (Lv. %d) appeared! Use catch to throw a Poke Ball.\n", cfg.localize("pokemon", wild.name), wild.level)
+	cfg.forward(wild.name)
+	return nil
+}
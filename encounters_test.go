@@ -0,0 +1,167 @@
+package main
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+const testAreaJSON = `{
+	"name": "test-lake-area",
+	"location": {"name": "test-lake"},
+	"pokemon_encounters": [
+		{
+			"pokemon": {"name": "psyduck"},
+			"version_details": [
+				{
+					"version": {"name": "diamond"},
+					"max_chance": 40,
+					"encounter_details": [
+						{"min_level": 20, "max_level": 22, "chance": 30, "method": {"name": "surf"}},
+						{"min_level": 24, "max_level": 26, "chance": 10, "method": {"name": "surf"}},
+						{"min_level": 15, "max_level": 15, "chance": 5, "method": {"name": "walk"}}
+					]
+				},
+				{
+					"version": {"name": "pearl"},
+					"max_chance": 60,
+					"encounter_details": [
+						{"min_level": 30, "max_level": 30, "chance": 60, "method": {"name": "surf"}}
+					]
+				}
+			]
+		},
+		{
+			"pokemon": {"name": "magikarp"},
+			"version_details": [
+				{
+					"version": {"name": "diamond"},
+					"max_chance": 100,
+					"encounter_details": [
+						{"min_level": 5, "max_level": 10, "chance": 100, "method": {"name": "old-rod"}}
+					]
+				}
+			]
+		}
+	]
+}`
+
+func testArea(t *testing.T) *locationAreaDetailResponse {
+	t.Helper()
+	var area locationAreaDetailResponse
+	if err := json.Unmarshal([]byte(testAreaJSON), &area); err != nil {
+		t.Fatal(err)
+	}
+	return &area
+}
+
+func TestEncounterSlots(t *testing.T) {
+	expected := []encounterSlot{
+		{pokemon: "psyduck", method: "surf", chance: 60, minLevel: 30, maxLevel: 30},
+		{pokemon: "psyduck", method: "walk", chance: 5, minLevel: 15, maxLevel: 15},
+		{pokemon: "magikarp", method: "old-rod", chance: 100, minLevel: 5, maxLevel: 10},
+	}
+
+	actual := encounterSlots(testArea(t))
+	if !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected slots %+v, got %+v", expected, actual)
+	}
+}
+
+func TestFindEncounterSlot(t *testing.T) {
+	slots := encounterSlots(testArea(t))
+
+	tests := []struct {
+		pokemon string
+		method  string
+		chance  int
+		fails   bool
+	}{
+		{pokemon: "psyduck", method: "", chance: 60},
+		{pokemon: "psyduck", method: "walk", chance: 5},
+		{pokemon: "magikarp", method: "", chance: 100},
+		{pokemon: "magikarp", method: "surf", fails: true},
+		{pokemon: "pikachu", method: "", fails: true},
+	}
+
+	for _, test := range tests {
+		slot, err := findEncounterSlot(slots, test.pokemon, test.method)
+		if (err != nil) != test.fails {
+			t.Errorf("%s by %q - Expected failure=%v, got error %v", test.pokemon, test.method, test.fails, err)
+			continue
+		}
+		if !test.fails && slot.chance != test.chance {
+			t.Errorf("%s by %q - Expected chance %d, got %d", test.pokemon, test.method, test.chance, slot.chance)
+		}
+	}
+}
+
+func TestRollEncounter(t *testing.T) {
+	slots := []encounterSlot{
+		{pokemon: "starly", method: "walk", chance: 50, minLevel: 2, maxLevel: 4},
+		{pokemon: "bidoof", method: "walk", chance: 40, minLevel: 3, maxLevel: 3},
+		{pokemon: "kricketot", method: "walk", chance: 10, minLevel: 3, maxLevel: 5},
+		{pokemon: "magikarp", method: "old-rod", chance: 100, minLevel: 5, maxLevel: 10},
+	}
+
+	// fixed returns the given rolls in order: first the slot, then the level.
+	fixed := func(rolls ...int) func(int) int {
+		return func(n int) int {
+			roll := rolls[0]
+			rolls = rolls[1:]
+			return roll
+		}
+	}
+
+	tests := []struct {
+		method   string
+		rolls    []int
+		expected wildPokemon
+	}{
+		{method: "walk", rolls: []int{0, 2}, expected: wildPokemon{name: "starly", level: 4, method: "walk"}},
+		{method: "walk", rolls: []int{50, 0}, expected: wildPokemon{name: "bidoof", level: 3, method: "walk"}},
+		{method: "walk", rolls: []int{99, 1}, expected: wildPokemon{name: "kricketot", level: 4, method: "walk"}},
+		{method: "old-rod", rolls: []int{42, 5}, expected: wildPokemon{name: "magikarp", level: 10, method: "old-rod"}},
+	}
+
+	for _, test := range tests {
+		wild, err := rollEncounter(slots, test.method, fixed(test.rolls...))
+		if err != nil {
+			t.Errorf("Method %s, rolls %v - Unexpected error: %v", test.method, test.rolls, err)
+			continue
+		}
+		if *wild != test.expected {
+			t.Errorf("Method %s, rolls %v - Expected %+v, got %+v", test.method, test.rolls, test.expected, *wild)
+		}
+	}
+
+	if _, err := rollEncounter(slots, "surf", fixed(0, 0)); err == nil {
+		t.Error("Expected an error for a method with no Pokemon, got none")
+	}
+}
+
+func TestExploreLeavesWildPokemonBehind(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"location-area/area-a", []byte(`{"name": "area-a", "pokemon_encounters": []}`))
+	cfg.Cache.Add(pokeAPIBaseURL+"location-area/area-b", []byte(`{"name": "area-b", "pokemon_encounters": []}`))
+	cfg.Location = "area-a"
+	cfg.Wild = &wildPokemon{name: "geodude", level: 7, method: "walk"}
+
+	if err := commandExplore(cfg, []string{"area-a"}); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Wild == nil {
+		t.Error("Expected the wild Pokemon to stay when exploring the same area")
+	}
+
+	if err := commandExplore(cfg, []string{"area-b"}); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Wild != nil {
+		t.Errorf("Expected the wild Pokemon to be left behind in area-a, got %+v", cfg.Wild)
+	}
+}
@@ -0,0 +1,346 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+func commandEvolutions(cfg *config, args []string) error {
+	pokemon, err := lookupPokemon(cfg, resourceName(args[0]))
+	if err != nil {
+		return err
+	}
+	chain, err := fetchChainFor(cfg, pokemon)
+	if err != nil {
+		return err
+	}
+	for _, line := range renderEvolutionChain(localizeChain(cfg, chain.Chain)) {
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func fetchChainFor(cfg *config, pokemon Pokemon) (*evolutionChainResponse, error) {
+	species, err := fetchSpecies(pokemon.Species.URL, cfg.Cache)
+	if err != nil {
+		return nil, err
+	}
+	if species.EvolutionChain.URL == "" {
+		return nil, fmt.Errorf("%s has no evolution chain", pokemon.Name)
+	}
+	return fetchEvolutionChain(species.EvolutionChain.URL, cfg.Cache)
+}
+
+// localizeChain replaces the species identifiers in a chain with their
+// names in the chosen language.
+func localizeChain(cfg *config, link chainLink) chainLink {
+	link.Species.Name = cfg.localize("pokemon", link.Species.Name)
+	evolvesTo := make([]chainLink, len(link.EvolvesTo))
+	for i, next := range link.EvolvesTo {
+		evolvesTo[i] = localizeChain(cfg, next)
+	}
+	link.EvolvesTo = evolvesTo
+	return link
+}
+
+// renderEvolutionChain draws the chain as a tree, with the conditions for
+// each evolution next to the species it evolves into.
+func renderEvolutionChain(root chainLink) []string {
+	lines := []string{root.Species.Name}
+	var walk func(link chainLink, indent string)
+	walk = func(link chainLink, indent string) {
+		for i, next := range link.EvolvesTo {
+			branch, childIndent := "├─ ", "│  "
+			if i == len(link.EvolvesTo)-1 {
+				branch, childIndent = "└─ ", "   "
+			}
+			lines = append(lines, fmt.Sprintf("%s%s%s (%s)", indent, branch, next.Species.Name, describeEvolution(next.EvolutionDetails)))
+			walk(next, indent+childIndent)
+		}
+	}
+	walk(root, "")
+	return lines
+}
+
+// describeEvolution explains how to evolve, e.g. "level 16" or "use
+// water-stone". Different games sometimes use different conditions, which
+// are joined with "or".
+func describeEvolution(details []evolutionDetail) string {
+	if len(details) == 0 {
+		return "unknown"
+	}
+	var options []string
+	for _, d := range details {
+		option := describeEvolutionDetail(d)
+		duplicate := false
+		for _, existing := range options {
+			if existing == option {
+				duplicate = true
+			}
+		}
+		if !duplicate {
+			options = append(options, option)
+		}
+	}
+	return strings.Join(options, " or ")
+}
+
+func describeEvolutionDetail(d evolutionDetail) string {
+	var parts []string
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel != nil {
+			parts = append(parts, fmt.Sprintf("level %d", *d.MinLevel))
+		} else {
+			parts = append(parts, "level up")
+		}
+	case "use-item":
+		if d.Item != nil {
+			parts = append(parts, "use "+d.Item.Name)
+		} else {
+			parts = append(parts, "use an item")
+		}
+	case "trade":
+		if d.TradeSpecies != nil {
+			parts = append(parts, "trade for "+d.TradeSpecies.Name)
+		} else {
+			parts = append(parts, "trade")
+		}
+	default:
+		parts = append(parts, d.Trigger.Name)
+	}
+
+	if d.HeldItem != nil {
+		parts = append(parts, "holding "+d.HeldItem.Name)
+	}
+	if d.KnownMove != nil {
+		parts = append(parts, "knowing "+d.KnownMove.Name)
+	}
+	if d.KnownMoveType != nil {
+		parts = append(parts, "knowing a "+d.KnownMoveType.Name+" move")
+	}
+	if d.MinHappiness != nil {
+		parts = append(parts, fmt.Sprintf("with friendship %d+", *d.MinHappiness))
+	}
+	if d.MinAffection != nil {
+		parts = append(parts, fmt.Sprintf("with affection %d+", *d.MinAffection))
+	}
+	if d.MinBeauty != nil {
+		parts = append(parts, fmt.Sprintf("with beauty %d+", *d.MinBeauty))
+	}
+	if d.TimeOfDay != "" {
+		parts = append(parts, "during the "+d.TimeOfDay)
+	}
+	if d.Location != nil {
+		parts = append(parts, "at "+d.Location.Name)
+	}
+	if d.Gender != nil {
+		parts = append(parts, "if "+genderName(*d.Gender))
+	}
+	if d.PartySpecies != nil {
+		parts = append(parts, "with "+d.PartySpecies.Name+" in the party")
+	}
+	if d.PartyType != nil {
+		parts = append(parts, "with a "+d.PartyType.Name+" type in the party")
+	}
+	if d.NeedsOverworldRain {
+		parts = append(parts, "while it rains")
+	}
+	if d.TurnUpsideDown {
+		parts = append(parts, "holding the console upside down")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// genderName names PokeAPI's gender IDs.
+func genderName(id int) string {
+	switch id {
+	case 1:
+		return "female"
+	case 2:
+		return "male"
+	}
+	return "genderless"
+}
+
+// findChainLink finds a species in an evolution chain.
+func findChainLink(link chainLink, species string) *chainLink {
+	if link.Species.Name == species {
+		return &link
+	}
+	for _, next := range link.EvolvesTo {
+		if found := findChainLink(next, species); found != nil {
+			return found
+		}
+	}
+	return nil
+}
+
+// evolveOptions are what the trainer does to help an evolution along.
+type evolveOptions struct {
+	item  string
+	trade bool
+}
+
+// unmetConditions lists what an owned Pokemon still needs to evolve with
+// one set of evolution conditions. An empty list means it can evolve.
+func unmetConditions(cfg *config, owned *OwnedPokemon, d evolutionDetail, opts evolveOptions, now time.Time) []string {
+	var unmet []string
+	switch d.Trigger.Name {
+	case "level-up":
+		if d.MinLevel != nil && owned.Level < *d.MinLevel {
+			unmet = append(unmet, fmt.Sprintf("reach level %d", *d.MinLevel))
+		}
+	case "use-item":
+		switch {
+		case d.Item == nil:
+			unmet = append(unmet, "use an unknown item")
+		case opts.item != d.Item.Name:
+			unmet = append(unmet, fmt.Sprintf("use a %s (--item %s)", d.Item.Name, d.Item.Name))
+		}
+	case "trade":
+		if !opts.trade {
+			unmet = append(unmet, "be traded (--trade)")
+		}
+	default:
+		unmet = append(unmet, fmt.Sprintf("%s evolutions aren't supported", d.Trigger.Name))
+	}
+
+	if d.TimeOfDay != "" && d.TimeOfDay != timeOfDay(now) {
+		unmet = append(unmet, "wait until "+d.TimeOfDay+"time")
+	}
+	if d.PartySpecies != nil || d.PartyType != nil {
+		found := false
+		for _, member := range partyMembers(cfg) {
+			if member == owned {
+				continue
+			}
+			if d.PartySpecies != nil && member.Species == d.PartySpecies.Name {
+				found = true
+			}
+			if d.PartyType != nil {
+				for _, t := range pokemonTypes(cfg.Pokedex[member.Species]) {
+					if t == d.PartyType.Name {
+						found = true
+					}
+				}
+			}
+		}
+		if !found {
+			unmet = append(unmet, "have the right Pokemon in your party")
+		}
+	}
+	if d.Location != nil {
+		area, err := currentArea(cfg)
+		if err != nil || area.Location.Name != d.Location.Name {
+			unmet = append(unmet, "be at "+d.Location.Name)
+		}
+	}
+	if d.Gender != nil && owned.Gender != genderName(*d.Gender) {
+		unmet = append(unmet, "be "+genderName(*d.Gender))
+	}
+	if d.KnownMove != nil && !slices.Contains(owned.Moves, d.KnownMove.Name) {
+		unmet = append(unmet, "know "+d.KnownMove.Name)
+	}
+	if d.KnownMoveType != nil && !knowsMoveOfType(cfg, owned, d.KnownMoveType.Name) {
+		unmet = append(unmet, "know a "+d.KnownMoveType.Name+" move")
+	}
+
+	// The game doesn't track these yet, so they can never be met.
+	if d.HeldItem != nil {
+		unmet = append(unmet, "hold a "+d.HeldItem.Name+" (held items aren't supported)")
+	}
+	if d.MinHappiness != nil || d.MinAffection != nil || d.MinBeauty != nil {
+		unmet = append(unmet, "be friendlier (friendship isn't tracked)")
+	}
+	if d.NeedsOverworldRain || d.TurnUpsideDown {
+		unmet = append(unmet, "wait for conditions the game doesn't simulate")
+	}
+	return unmet
+}
+
+// timeOfDay maps the clock to PokeAPI's "day" and "night".
+func timeOfDay(now time.Time) string {
+	if h := now.Hour(); h >= 6 && h < 18 {
+		return "day"
+	}
+	return "night"
+}
+
+func commandEvolve(cfg *config, args []string) error {
+	owned, err := findOwned(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	target := ""
+	if len(args) == 2 {
+		target = resourceName(args[1])
+	}
+	opts := evolveOptions{}
+	if item, ok := cfg.flag("item"); ok {
+		opts.item = resourceName(item)
+	}
+	_, opts.trade = cfg.flag("trade")
+
+	pokemon := cfg.Pokedex[owned.Species]
+	chain, err := fetchChainFor(cfg, pokemon)
+	if err != nil {
+		return err
+	}
+	link := findChainLink(chain.Chain, pokemon.Species.Name)
+	if link == nil || len(link.EvolvesTo) == 0 {
+		return fmt.Errorf("%s doesn't evolve", pokemon.Species.Name)
+	}
+
+	now := time.Now()
+	var reasons []string
+	for _, next := range link.EvolvesTo {
+		if target != "" && next.Species.Name != target {
+			continue
+		}
+		for _, detail := range next.EvolutionDetails {
+			unmet := unmetConditions(cfg, owned, detail, opts, now)
+			if len(unmet) == 0 {
+				return evolveInto(cfg, owned, next.Species.Name)
+			}
+			reasons = append(reasons, fmt.Sprintf("  into %s: %s", next.Species.Name, strings.Join(unmet, ", ")))
+		}
+	}
+	if len(reasons) == 0 {
+		return fmt.Errorf("%s can't evolve into %s", pokemon.Species.Name, target)
+	}
+	return fmt.Errorf("%s can't evolve yet, it needs to:\n%s", owned.displayName(cfg), strings.Join(reasons, "\n"))
+}
+
+// evolveInto turns an owned Pokemon into the default form of another
+// species.
+func evolveInto(cfg *config, owned *OwnedPokemon, speciesName string) error {
+	species, err := fetchSpecies(pokeAPIBaseURL+"pokemon-species/"+speciesName, cfg.Cache)
+	if err != nil {
+		return err
+	}
+	res, err := fetchPokemon(defaultVarietyURL(species), cfg.Cache)
+	if err != nil {
+		return err
+	}
+	evolved := convertToPokemon(res)
+
+	fmt.Printf("What? %s is evolving!\n", owned.displayName(cfg))
+	previous := owned.Species
+	owned.Species = evolved.Name
+	owned.GrowthRate = species.GrowthRate.Name
+	owned.Form = rollForm(evolved, cfg.Rand.Intn)
+	if _, ok := cfg.Pokedex[evolved.Name]; !ok {
+		cfg.Pokedex[evolved.Name] = evolved
+		fmt.Printf("%s's data was added to your Pokedex!\n", evolved.Name)
+	}
+	cfg.markSeen(evolved.Name)
+	fmt.Printf("Congratulations! Your %s evolved into %s!\n", previous, evolved.Name)
+
+	if err := saveGame(cfg); err != nil {
+		return fmt.Errorf("could not save evolution: %w", err)
+	}
+	return nil
+}
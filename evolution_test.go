@@ -0,0 +1,178 @@
+package main
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+func TestDescribeEvolution(t *testing.T) {
+	level := 16
+	happiness := 160
+	tests := []struct {
+		details  []evolutionDetail
+		expected string
+	}{
+		{
+			details:  []evolutionDetail{{Trigger: namedResource{Name: "level-up"}, MinLevel: &level}},
+			expected: "level 16",
+		},
+		{
+			details:  []evolutionDetail{{Trigger: namedResource{Name: "use-item"}, Item: &namedResource{Name: "water-stone"}}},
+			expected: "use water-stone",
+		},
+		{
+			details:  []evolutionDetail{{Trigger: namedResource{Name: "trade"}, HeldItem: &namedResource{Name: "metal-coat"}}},
+			expected: "trade, holding metal-coat",
+		},
+		{
+			details:  []evolutionDetail{{Trigger: namedResource{Name: "level-up"}, MinHappiness: &happiness, TimeOfDay: "night"}},
+			expected: "level up, with friendship 160+, during the night",
+		},
+		{
+			details: []evolutionDetail{
+				{Trigger: namedResource{Name: "level-up"}, Location: &namedResource{Name: "eterna-forest"}},
+				{Trigger: namedResource{Name: "use-item"}, Item: &namedResource{Name: "leaf-stone"}},
+			},
+			expected: "level up, at eterna-forest or use leaf-stone",
+		},
+		{
+			details:  nil,
+			expected: "unknown",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := describeEvolution(test.details); actual != test.expected {
+			t.Errorf("Expected %q, got %q", test.expected, actual)
+		}
+	}
+}
+
+func TestRenderEvolutionChain(t *testing.T) {
+	stone := func(item string) []evolutionDetail {
+		return []evolutionDetail{{Trigger: namedResource{Name: "use-item"}, Item: &namedResource{Name: item}}}
+	}
+	chain := chainLink{
+		Species: namedResource{Name: "oddish"},
+		EvolvesTo: []chainLink{{
+			Species:          namedResource{Name: "gloom"},
+			EvolutionDetails: []evolutionDetail{{Trigger: namedResource{Name: "level-up"}}},
+			EvolvesTo: []chainLink{
+				{Species: namedResource{Name: "vileplume"}, EvolutionDetails: stone("leaf-stone")},
+				{Species: namedResource{Name: "bellossom"}, EvolutionDetails: stone("sun-stone")},
+			},
+		}},
+	}
+
+	expected := []string{
+		"oddish",
+		"└─ gloom (level up)",
+		"   ├─ vileplume (use leaf-stone)",
+		"   └─ bellossom (use sun-stone)",
+	}
+	actual := renderEvolutionChain(chain)
+	if strings.Join(actual, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected:\n%s\ngot:\n%s", strings.Join(expected, "\n"), strings.Join(actual, "\n"))
+	}
+}
+
+func TestUnmetConditions(t *testing.T) {
+	cfg := newTestConfig(t)
+	owned := addOwned(cfg, Pokemon{Name: "charmander"}, 10)
+	level := 16
+	noon := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	levelUp := evolutionDetail{Trigger: namedResource{Name: "level-up"}, MinLevel: &level}
+	if unmet := unmetConditions(cfg, owned, levelUp, evolveOptions{}, noon); len(unmet) != 1 {
+		t.Errorf("Expected a level 10 Pokemon to be too low for level 16, got %v", unmet)
+	}
+	owned.Level = 16
+	if unmet := unmetConditions(cfg, owned, levelUp, evolveOptions{}, noon); len(unmet) != 0 {
+		t.Errorf("Expected a level 16 Pokemon to evolve, got %v", unmet)
+	}
+
+	night := evolutionDetail{Trigger: namedResource{Name: "level-up"}, TimeOfDay: "night"}
+	if unmet := unmetConditions(cfg, owned, night, evolveOptions{}, noon); len(unmet) != 1 {
+		t.Errorf("Expected a night evolution not to happen at noon, got %v", unmet)
+	}
+
+	item := evolutionDetail{Trigger: namedResource{Name: "use-item"}, Item: &namedResource{Name: "fire-stone"}}
+	if unmet := unmetConditions(cfg, owned, item, evolveOptions{item: "thunder-stone"}, noon); len(unmet) != 1 {
+		t.Errorf("Expected an item evolution to need the right item, got %v", unmet)
+	}
+	if unmet := unmetConditions(cfg, owned, item, evolveOptions{item: "fire-stone"}, noon); len(unmet) != 0 {
+		t.Errorf("Expected an item evolution with the item to happen, got %v", unmet)
+	}
+
+	trade := evolutionDetail{Trigger: namedResource{Name: "trade"}}
+	if unmet := unmetConditions(cfg, owned, trade, evolveOptions{trade: true}, noon); len(unmet) != 0 {
+		t.Errorf("Expected a trade evolution with --trade to happen, got %v", unmet)
+	}
+
+	happiness := 220
+	friendship := evolutionDetail{Trigger: namedResource{Name: "level-up"}, MinHappiness: &happiness}
+	if unmet := unmetConditions(cfg, owned, friendship, evolveOptions{}, noon); len(unmet) == 0 {
+		t.Error("Expected friendship evolutions to be reported as unmet")
+	}
+}
+
+func TestCommandEvolve(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon/charmander", []byte(`{
+		"name": "charmander",
+		"species": {"name": "charmander", "url": "https://pokeapi.co/api/v2/pokemon-species/4/"}
+	}`))
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon-species/4/", []byte(`{
+		"name": "charmander",
+		"evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/2/"}
+	}`))
+	cfg.Cache.Add(pokeAPIBaseURL+"evolution-chain/2/", []byte(`{
+		"id": 2,
+		"chain": {
+			"species": {"name": "charmander"},
+			"evolves_to": [{
+				"species": {"name": "charmeleon"},
+				"evolution_details": [{"trigger": {"name": "level-up"}, "min_level": 16}]
+			}]
+		}
+	}`))
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon-species/charmeleon", []byte(`{
+		"name": "charmeleon",
+		"varieties": [{"is_default": true, "pokemon": {"name": "charmeleon", "url": "https://pokeapi.co/api/v2/pokemon/5/"}}]
+	}`))
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon-species/5/", []byte(`{
+		"name": "charmeleon",
+		"evolution_chain": {"url": "https://pokeapi.co/api/v2/evolution-chain/2/"}
+	}`))
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon/5/", []byte(`{
+		"name": "charmeleon",
+		"species": {"name": "charmeleon", "url": "https://pokeapi.co/api/v2/pokemon-species/5/"}
+	}`))
+
+	charmander, err := lookupPokemon(cfg, "charmander")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	owned := addOwned(cfg, charmander, 12)
+
+	if err := commandEvolve(cfg, []string{"charmander"}); err == nil {
+		t.Error("Expected a level 12 charmander not to evolve")
+	}
+	owned.Level = 16
+	if err := commandEvolve(cfg, []string{"charmander"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if owned.Species != "charmeleon" {
+		t.Errorf("Expected the Pokemon to become charmeleon, got %s", owned.Species)
+	}
+	if _, ok := cfg.Pokedex["charmeleon"]; !ok || !cfg.Seen["charmeleon"] {
+		t.Error("Expected the evolution to be added to the Pokedex")
+	}
+	if err := commandEvolve(cfg, []string{"charmeleon"}); err == nil {
+		t.Error("Expected the last stage of a chain not to evolve")
+	}
+}
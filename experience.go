@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+)
+
+const maxLevel = 100
+
+// loadGrowthRate returns the experience table of a growth rate, where
+// levels[n] is the total experience needed to reach level n.
+func loadGrowthRate(cfg *config, name string) ([]int, error) {
+	if levels, ok := cfg.GrowthRates[name]; ok {
+		return levels, nil
+	}
+	res, err := fetchGrowthRate(pokeAPIBaseURL+"growth-rate/"+name, cfg.Cache)
+	if err != nil {
+		return nil, fmt.Errorf("could not load growth rate %s: %w", name, err)
+	}
+
+	levels := make([]int, maxLevel+1)
+	for _, l := range res.Levels {
+		if l.Level >= 1 && l.Level <= maxLevel {
+			levels[l.Level] = l.Experience
+		}
+	}
+	if cfg.GrowthRates == nil {
+		cfg.GrowthRates = make(map[string][]int)
+	}
+	cfg.GrowthRates[name] = levels
+	return levels, nil
+}
+
+// ownedGrowthRate returns the experience table of an owned Pokemon,
+// looking up its species' growth rate if it isn't known yet.
+func ownedGrowthRate(cfg *config, owned *OwnedPokemon) ([]int, error) {
+	if owned.GrowthRate == "" {
+		species, err := fetchSpecies(cfg.Pokedex[owned.Species].Species.URL, cfg.Cache)
+		if err != nil {
+			return nil, err
+		}
+		owned.GrowthRate = species.GrowthRate.Name
+	}
+	return loadGrowthRate(cfg, owned.GrowthRate)
+}
+
+// levelForExperience is the highest level whose experience has been
+// reached.
+func levelForExperience(levels []int, experience int) int {
+	level := sort.Search(maxLevel, func(i int) bool {
+		return levels[i+1] > experience
+	})
+	return max(level, 1)
+}
+
+// experienceYield is the experience for defeating or catching a Pokemon,
+// using the generation 5 formula that gives more to lower-level winners.
+func experienceYield(baseExperience, defeatedLevel, winnerLevel int) int {
+	scale := math.Pow(float64(2*defeatedLevel+10)/float64(defeatedLevel+winnerLevel+10), 2.5)
+	return int(float64(baseExperience*defeatedLevel)/5*scale) + 1
+}
+
+// gainExperience adds experience to an owned Pokemon and raises its level
+// once it has enough, reporting each new level.
+func gainExperience(cfg *config, owned *OwnedPokemon, amount int) error {
+	levels, err := ownedGrowthRate(cfg, owned)
+	if err != nil {
+		return err
+	}
+	// Pokemon caught before experience was tracked start from the
+	// minimum for their level.
+	experience := max(owned.Experience, levels[owned.Level])
+	owned.Experience = min(experience+amount, levels[maxLevel])
+	fmt.Printf("%s gained %d experience points!\n", owned.displayName(cfg), amount)
+
+	for level := levelForExperience(levels, owned.Experience); owned.Level < level; {
+		owned.Level++
+		fmt.Printf("%s grew to Lv. %d!\n", owned.displayName(cfg), owned.Level)
+	}
+	return nil
+}
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"testing"
+)
+
+// mediumFastLevels is the medium-fast growth rate, where level n needs n³
+// experience.
+func mediumFastLevels() []int {
+	levels := make([]int, maxLevel+1)
+	for n := 2; n <= maxLevel; n++ {
+		levels[n] = n * n * n
+	}
+	return levels
+}
+
+func TestLevelForExperience(t *testing.T) {
+	levels := mediumFastLevels()
+	tests := []struct {
+		experience int
+		expected   int
+	}{
+		{experience: 0, expected: 1},
+		{experience: 125, expected: 5},
+		{experience: 215, expected: 5},
+		{experience: 216, expected: 6},
+		{experience: 1000000, expected: 100},
+		{experience: 2000000, expected: 100},
+	}
+
+	for _, test := range tests {
+		if actual := levelForExperience(levels, test.experience); actual != test.expected {
+			t.Errorf("Experience: %d - Expected level %d, got %d", test.experience, test.expected, actual)
+		}
+	}
+}
+
+func TestExperienceYield(t *testing.T) {
+	if actual := experienceYield(64, 5, 5); actual != 65 {
+		t.Errorf("Expected 65 experience for an even fight, got %d", actual)
+	}
+	if experienceYield(64, 5, 2) <= experienceYield(64, 5, 20) {
+		t.Error("Expected lower-level winners to get more experience")
+	}
+}
+
+func TestGainExperience(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.GrowthRates = map[string][]int{"medium": mediumFastLevels()}
+	owned := addOwned(cfg, Pokemon{Name: "pidgey"}, 5)
+	owned.GrowthRate = "medium"
+
+	if err := gainExperience(cfg, owned, 100); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if owned.Experience != 225 || owned.Level != 6 {
+		t.Errorf("Expected 225 experience at level 6, got %d at level %d", owned.Experience, owned.Level)
+	}
+
+	if err := gainExperience(cfg, owned, 5000000); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if owned.Experience != 1000000 || owned.Level != maxLevel {
+		t.Errorf("Expected experience to stop at level 100, got %d at level %d", owned.Experience, owned.Level)
+	}
+}
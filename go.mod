@@ -1,3 +1,7 @@
 module github.com/Fearcon14/pokedexCLI
 
 go 1.24.5
+
+require go.starlark.net v0.0.0-20260210143700-b62fd896b91b
+
+require golang.org/x/sys v0.0.0-20220715151400-c0bba94af5f8 // indirect
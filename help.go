@@ -0,0 +1,177 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	categoryGeneral     = "General"
+	categoryExploration = "Exploration"
+	categoryPokemon     = "Pokemon"
+	categoryItems       = "Items"
+	categoryBattle      = "Battle"
+	categoryShortcuts   = "Shortcuts"
+	categoryAutomation  = "Automation"
+)
+
+// categoryOrder is the order categories are listed in by help. Categories
+// missing from it are listed afterwards in alphabetical order.
+var categoryOrder = []string{
+	categoryGeneral,
+	categoryExploration,
+	categoryPokemon,
+	categoryItems,
+	categoryBattle,
+	categoryShortcuts,
+	categoryAutomation,
+}
+
+func commandHelp(cfg *config, args []string) error {
+	if len(args) == 1 {
+		return printCommandHelp(cfg, strings.ToLower(args[0]))
+	}
+
+	fmt.Println("Welcome to the Pokedex!")
+	fmt.Println("Usage:")
+	for _, category := range sortedCategories() {
+		fmt.Printf("\n%s:\n", category)
+		for _, command := range commandsInCategory(category) {
+			fmt.Printf("  %s: %s\n", command.usageLine(), command.description)
+		}
+	}
+	fmt.Println()
+	printAliases(cfg)
+	printMacros(cfg)
+	fmt.Println("Commands can be chained with '|', e.g. explore <area> | filter type=rock | catch")
+	fmt.Println("Type 'help <command>' for details about a command.")
+	return nil
+}
+
+func printCommandHelp(cfg *config, name string) error {
+	if target, ok := cfg.Settings.Aliases[name]; ok {
+		fmt.Printf("%s is an alias for: %s\n", name, target)
+		return nil
+	}
+	if body, ok := cfg.Settings.Macros[name]; ok {
+		fmt.Printf("%s is a macro for: %s\n", name, body)
+		return nil
+	}
+	command, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command: %s", name)
+	}
+
+	fmt.Printf("%s - %s\n", command.name, command.description)
+	fmt.Printf("Usage: %s\n", command.usageLine())
+	if len(command.args) > 0 {
+		fmt.Println("Arguments:")
+		for _, arg := range command.args {
+			note := ""
+			if arg.optional {
+				note = " (optional)"
+			}
+			fmt.Printf("  %s: %s%s\n", arg.name, arg.description, note)
+		}
+	}
+	if len(command.flags) > 0 {
+		fmt.Println("Options:")
+		for _, flag := range command.flags {
+			name := "--" + flag.name
+			if flag.value != "" {
+				name += " <" + flag.value + ">"
+			}
+			fmt.Printf("  %s: %s\n", name, flag.description)
+		}
+	}
+	if len(command.examples) > 0 {
+		fmt.Println("Examples:")
+		for _, example := range command.examples {
+			fmt.Printf("  %s\n", example)
+		}
+	}
+	return nil
+}
+
+// usageLine renders the command's syntax, e.g. "alias [name] [command...]".
+func (c cliCommand) usageLine() string {
+	if c.usage != "" {
+		return c.usage
+	}
+	parts := []string{c.name}
+	for _, arg := range c.args {
+		name := arg.name
+		if arg.variadic {
+			name += "..."
+		}
+		if arg.optional {
+			parts = append(parts, "["+name+"]")
+		} else {
+			parts = append(parts, "<"+name+">")
+		}
+	}
+	for _, flag := range c.flags {
+		if flag.value != "" {
+			parts = append(parts, "[--"+flag.name+" "+flag.value+"]")
+		} else {
+			parts = append(parts, "[--"+flag.name+"]")
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// validateArgs checks the number of positional arguments against the
+// command's argument specs, so callbacks only see argument lists they can
+// handle.
+func validateArgs(c cliCommand, args []string) error {
+	required := 0
+	variadic := false
+	for _, arg := range c.args {
+		if !arg.optional {
+			required++
+		}
+		if arg.variadic {
+			variadic = true
+		}
+	}
+
+	if len(args) < required || (!variadic && len(args) > len(c.args)) {
+		return fmt.Errorf("usage: %s", c.usageLine())
+	}
+	return nil
+}
+
+func sortedCategories() []string {
+	seen := make(map[string]bool)
+	for _, command := range commands {
+		seen[command.category] = true
+	}
+
+	var categories []string
+	for _, category := range categoryOrder {
+		if seen[category] {
+			categories = append(categories, category)
+			delete(seen, category)
+		}
+	}
+	var rest []string
+	for category := range seen {
+		rest = append(rest, category)
+	}
+	sort.Strings(rest)
+	return append(categories, rest...)
+}
+
+func commandsInCategory(category string) []cliCommand {
+	var list []cliCommand
+	for _, command := range commands {
+		if command.category == category {
+			list = append(list, command)
+		}
+	}
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].name < list[j].name
+	})
+	return list
+}
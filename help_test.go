@@ -0,0 +1,88 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestUsageLine(t *testing.T) {
+	tests := []struct {
+		command  cliCommand
+		expected string
+	}{
+		{
+			command:  cliCommand{name: "pokedex"},
+			expected: "pokedex",
+		},
+		{
+			command: cliCommand{name: "alias", args: []argSpec{
+				{name: "name", optional: true},
+				{name: "command", optional: true, variadic: true},
+			}},
+			expected: "alias [name] [command...]",
+		},
+		{
+			command:  cliCommand{name: "explore", args: []argSpec{{name: "location-name"}}},
+			expected: "explore <location-name>",
+		},
+		{
+			command:  cliCommand{name: "macro", usage: "macro <name> = <command>"},
+			expected: "macro <name> = <command>",
+		},
+	}
+
+	for _, test := range tests {
+		if actual := test.command.usageLine(); actual != test.expected {
+			t.Errorf("Command: %s - Expected %q, got %q", test.command.name, test.expected, actual)
+		}
+	}
+}
+
+func TestValidateArgs(t *testing.T) {
+	explore := cliCommand{name: "explore", args: []argSpec{{name: "location-name"}}}
+	help := cliCommand{name: "help", args: []argSpec{{name: "command", optional: true}}}
+	alias := cliCommand{name: "alias", args: []argSpec{
+		{name: "name", optional: true},
+		{name: "command", optional: true, variadic: true},
+	}}
+
+	tests := []struct {
+		command cliCommand
+		args    []string
+		valid   bool
+	}{
+		{command: explore, args: []string{"area"}, valid: true},
+		{command: explore, args: nil, valid: false},
+		{command: explore, args: []string{"a", "b"}, valid: false},
+		{command: help, args: nil, valid: true},
+		{command: help, args: []string{"catch"}, valid: true},
+		{command: help, args: []string{"catch", "more"}, valid: false},
+		{command: alias, args: []string{"e", "explore", "area"}, valid: true},
+	}
+
+	for _, test := range tests {
+		err := validateArgs(test.command, test.args)
+		if (err == nil) != test.valid {
+			t.Errorf("Command: %s Args: %q - Expected valid=%v, got error %v",
+				test.command.name, test.args, test.valid, err)
+		}
+	}
+}
+
+func TestCommandsAreDocumented(t *testing.T) {
+	for key, command := range commands {
+		if command.name != key {
+			t.Errorf("Command %q is registered under %q", command.name, key)
+		}
+		if command.description == "" {
+			t.Errorf("Command %q has no description", key)
+		}
+		if command.category == "" {
+			t.Errorf("Command %q has no category", key)
+		}
+		for i, arg := range command.args {
+			if arg.variadic && i != len(command.args)-1 {
+				t.Errorf("Command %q has a variadic argument that is not last", key)
+			}
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const (
+	defaultBall = "poke-ball"
+	// masterBallModifier marks a ball that never fails.
+	masterBallModifier = 255
+)
+
+// ballModifiers are the catch rate multipliers of the Poke Balls that can
+// be used with catch. PokeAPI's item data doesn't include them.
+var ballModifiers = map[string]float64{
+	"poke-ball":   1,
+	"great-ball":  1.5,
+	"ultra-ball":  2,
+	"master-ball": masterBallModifier,
+}
+
+// newGameInventory is the bag a new trainer starts with. Plain Poke Balls
+// never run out, so they aren't counted.
+func newGameInventory() map[string]int {
+	return map[string]int{
+		"great-ball":  5,
+		"ultra-ball":  2,
+		"master-ball": 1,
+	}
+}
+
+// ballName turns "ultra", "Ultra Ball" or "ultra-ball" into "ultra-ball".
+func ballName(s string) string {
+	name := resourceName(s)
+	if name == "poke" || name == "poké" {
+		return defaultBall
+	}
+	if !strings.HasSuffix(name, "-ball") {
+		name += "-ball"
+	}
+	return name
+}
+
+// attemptCatch runs the generation III-IV shake checks for a wild Pokemon
+// at full health. intn is the source of randomness, e.g. cfg.Rand.Intn. It
+// returns the number of shakes (up to 4) and whether the Pokemon was
+// caught, which happens when the ball shakes four times.
+func attemptCatch(captureRate int, ballModifier float64, intn func(int) int) (int, bool) {
+	a := catchValue(captureRate, ballModifier)
+	if a >= 255 {
+		return 4, true
+	}
+	b := shakeThreshold(a)
+	for shakes := 0; shakes < 4; shakes++ {
+		if float64(intn(65536)) >= b {
+			return shakes, false
+		}
+	}
+	return 4, true
+}
+
+// catchValue is the modified catch rate "a" for a Pokemon at full HP:
+// ((3*maxHP - 2*HP) * rate * ball) / (3*maxHP) = rate * ball / 3.
+func catchValue(captureRate int, ballModifier float64) float64 {
+	return float64(captureRate) * ballModifier / 3
+}
+
+// shakeThreshold is the value a random number below 65536 has to stay
+// under for the ball to shake once.
+func shakeThreshold(a float64) float64 {
+	if a <= 0 {
+		return 0
+	}
+	return 1048560 / math.Sqrt(math.Sqrt(16711680/a))
+}
+
+func hasBall(cfg *config, ball string) bool {
+	return ball == defaultBall || cfg.Inventory[ball] > 0
+}
+
+// useBall takes a thrown ball out of the bag.
+func useBall(cfg *config, ball string) {
+	if ball != defaultBall {
+		cfg.Inventory[ball]--
+	}
+}
+
+// lookupItem fetches an item's PokeAPI data and returns its English name
+// and short effect.
+func lookupItem(cfg *config, name string) (string, string, error) {
+	item, err := fetchItem(pokeAPIBaseURL+"item/"+name, cfg.Cache)
+	if err != nil {
+		return "", "", fmt.Errorf("could not look up %s: %w", name, err)
+	}
+	displayName := item.Name
+	for _, n := range item.Names {
+		if n.Language.Name == "en" {
+			displayName = n.Name
+		}
+	}
+	effect := ""
+	for _, e := range item.EffectEntries {
+		if e.Language.Name == "en" {
+			effect = strings.Join(strings.Fields(e.ShortEffect), " ")
+		}
+	}
+	return displayName, effect, nil
+}
+
+func commandBag(cfg *config, args []string) error {
+	_ = args
+	names := make([]string, 0, len(cfg.Inventory))
+	for name, count := range cfg.Inventory {
+		if count > 0 && name != defaultBall {
+			names = append(names, name)
+		}
+	}
+	if len(names) == 0 {
+		fmt.Println("Your bag is empty, but Poke Balls never run out")
+		return nil
+	}
+	sort.Strings(names)
+
+	fmt.Println("Your bag (Poke Balls never run out):")
+	for _, name := range names {
+		displayName, effect, err := lookupItem(cfg, name)
+		if err != nil {
+			fmt.Printf("  - %s x%d\n", name, cfg.Inventory[name])
+			continue
+		}
+		fmt.Printf("  - %s x%d: %s\n", displayName, cfg.Inventory[name], effect)
+	}
+	return nil
+}
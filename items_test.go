@@ -0,0 +1,116 @@
+package main
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+func TestBallName(t *testing.T) {
+	tests := map[string]string{
+		"ultra":       "ultra-ball",
+		"Ultra Ball":  "ultra-ball",
+		"great-ball":  "great-ball",
+		"poke":        "poke-ball",
+		"MASTER":      "master-ball",
+		"poke-ball":   "poke-ball",
+		"Premier":     "premier-ball",
+		"  great   ":  "great-ball",
+		"master ball": "master-ball",
+	}
+
+	for input, expected := range tests {
+		if actual := ballName(input); actual != expected {
+			t.Errorf("Input: %q - Expected %q, got %q", input, expected, actual)
+		}
+	}
+}
+
+// catchChance is the probability attemptCatch succeeds.
+func catchChance(captureRate int, ballModifier float64) float64 {
+	a := catchValue(captureRate, ballModifier)
+	if a >= 255 {
+		return 1
+	}
+	return math.Pow(shakeThreshold(a)/65536, 4)
+}
+
+func TestCatchChance(t *testing.T) {
+	tests := []struct {
+		captureRate int
+		ball        float64
+		expected    float64
+	}{
+		{captureRate: 255, ball: ballModifiers["poke-ball"], expected: 0.333},
+		{captureRate: 255, ball: ballModifiers["ultra-ball"], expected: 0.667},
+		{captureRate: 45, ball: ballModifiers["great-ball"], expected: 0.088},
+		{captureRate: 3, ball: ballModifiers["master-ball"], expected: 1},
+		{captureRate: 0, ball: ballModifiers["ultra-ball"], expected: 0},
+	}
+
+	for _, test := range tests {
+		actual := catchChance(test.captureRate, test.ball)
+		if math.Abs(actual-test.expected) > 0.001 {
+			t.Errorf("Rate %d, ball %g - Expected %.3f, got %.3f", test.captureRate, test.ball, test.expected, actual)
+		}
+	}
+
+	previous := 0.0
+	for _, ball := range []string{"poke-ball", "great-ball", "ultra-ball", "master-ball"} {
+		chance := catchChance(45, ballModifiers[ball])
+		if chance <= previous {
+			t.Errorf("Expected %s to be better than the previous ball, got %.3f <= %.3f", ball, chance, previous)
+		}
+		previous = chance
+	}
+}
+
+func TestAttemptCatch(t *testing.T) {
+	always := func(n int) func(int) int {
+		return func(int) int { return n }
+	}
+
+	if shakes, caught := attemptCatch(45, 1, always(0)); !caught || shakes != 4 {
+		t.Errorf("Expected the lowest roll to catch, got %d shakes, caught=%v", shakes, caught)
+	}
+	if shakes, caught := attemptCatch(45, 1, always(65535)); caught || shakes != 0 {
+		t.Errorf("Expected the highest roll to break out at once, got %d shakes, caught=%v", shakes, caught)
+	}
+	if _, caught := attemptCatch(3, masterBallModifier, always(65535)); !caught {
+		t.Error("Expected the Master Ball to never fail")
+	}
+}
+
+func TestCatchWithEmptyBag(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Inventory = map[string]int{}
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon/magikarp", []byte(`{
+		"id": 129, "name": "magikarp", "species": {"name": "magikarp", "url": "`+pokeAPIBaseURL+`pokemon-species/129/"}
+	}`))
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon-species/129/", []byte(`{
+		"name": "magikarp", "capture_rate": 255, "gender_rate": 4, "growth_rate": {"name": "slow"}
+	}`))
+	cfg.Cache.Add(pokeAPIBaseURL+"nature?limit=100", []byte(`{"results": [{"name": "hardy"}]}`))
+	cfg.GrowthRates = map[string][]int{"slow": make([]int, maxLevel+1)}
+
+	cfg.Flags = map[string]string{"ball": "great"}
+	if err := commandCatch(cfg, []string{"magikarp"}); err == nil {
+		t.Error("Expected an error for a Great Ball that isn't in the bag")
+	}
+
+	cfg.Flags = nil
+	for i := 0; i < 20; i++ {
+		if err := commandCatch(cfg, []string{"magikarp"}); err != nil {
+			t.Fatalf("Expected Poke Balls to never run out, got %v", err)
+		}
+	}
+	if len(cfg.Box) == 0 {
+		t.Error("Expected magikarp to be caught with Poke Balls from an empty bag")
+	}
+	if cfg.Inventory[defaultBall] != 0 {
+		t.Errorf("Expected Poke Balls not to be counted, got %d", cfg.Inventory[defaultBall])
+	}
+}
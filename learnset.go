@@ -0,0 +1,170 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// learnMethodOrder sorts the common ways to learn a move first; the rarer
+// methods come after them in alphabetical order.
+var learnMethodOrder = map[string]int{
+	"level-up": 1,
+	"machine":  2,
+	"egg":      3,
+	"tutor":    4,
+}
+
+type learnsetEntry struct {
+	move   string
+	method string
+	level  int
+}
+
+// learnset lists the moves a Pokemon learns in one version group,
+// optionally only those learned with method.
+func learnset(p Pokemon, game, method string) []learnsetEntry {
+	var entries []learnsetEntry
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			if detail.VersionGroup.Name != game {
+				continue
+			}
+			if method != "" && detail.MoveLearnMethod.Name != method {
+				continue
+			}
+			entries = append(entries, learnsetEntry{
+				move:   move.Move.Name,
+				method: detail.MoveLearnMethod.Name,
+				level:  detail.LevelLearnedAt,
+			})
+		}
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		if a.method != b.method {
+			orderA, orderB := learnMethodOrder[a.method], learnMethodOrder[b.method]
+			if orderA == 0 || orderB == 0 {
+				if orderA != orderB {
+					return orderB == 0
+				}
+				return a.method < b.method
+			}
+			return orderA < orderB
+		}
+		if a.level != b.level {
+			return a.level < b.level
+		}
+		return a.move < b.move
+	})
+	return entries
+}
+
+// versionGroups lists the version groups a Pokemon has moves in, oldest
+// first.
+func versionGroups(p Pokemon) []string {
+	ids := make(map[string]int)
+	for _, move := range p.Moves {
+		for _, detail := range move.VersionGroupDetails {
+			ids[detail.VersionGroup.Name] = resourceID(detail.VersionGroup.URL)
+		}
+	}
+	groups := make([]string, 0, len(ids))
+	for name := range ids {
+		groups = append(groups, name)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if ids[groups[i]] != ids[groups[j]] {
+			return ids[groups[i]] < ids[groups[j]]
+		}
+		return groups[i] < groups[j]
+	})
+	return groups
+}
+
+// resourceID reads the ID at the end of a PokeAPI URL, or returns 0.
+func resourceID(url string) int {
+	parts := strings.Split(strings.TrimSuffix(url, "/"), "/")
+	id, err := strconv.Atoi(parts[len(parts)-1])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+func commandMoves(cfg *config, args []string) error {
+	pokemon, err := lookupPokemon(cfg, resourceName(args[0]))
+	if err != nil {
+		return err
+	}
+	groups := versionGroups(pokemon)
+	if len(groups) == 0 {
+		return fmt.Errorf("%s doesn't learn any moves", pokemon.Name)
+	}
+
+	// Without --game, show the most recent game the Pokemon is in.
+	game := groups[len(groups)-1]
+	if value, ok := cfg.flag("game"); ok {
+		game = resourceName(value)
+	}
+	method := ""
+	if value, ok := cfg.flag("method"); ok {
+		method = resourceName(value)
+	}
+
+	entries := learnset(pokemon, game, method)
+	if len(entries) == 0 {
+		if method != "" {
+			return fmt.Errorf("%s learns no moves by %s in %s", pokemon.Name, method, game)
+		}
+		return fmt.Errorf("%s learns no moves in %s; try one of: %s", pokemon.Name, game, strings.Join(groups, ", "))
+	}
+
+	if cfg.PipeOutput != nil {
+		names := make([]string, len(entries))
+		for i, entry := range entries {
+			names[i] = entry.move
+		}
+		cfg.emit("", names)
+		return nil
+	}
+
+	moveNames := make([]string, len(entries))
+	width := len("Move")
+	for i, entry := range entries {
+		moveNames[i] = cfg.localize("move", entry.move)
+		width = max(width, utf8.RuneCountInString(moveNames[i]))
+	}
+	fmt.Printf("Moves %s learns in %s:\n", cfg.localize("pokemon", pokemon.Name), game)
+	fmt.Printf("  %-4s %-*s  %s\n", "Lv.", width, "Move", "Method")
+	for i, entry := range entries {
+		level := "-"
+		if entry.method == "level-up" {
+			level = strconv.Itoa(entry.level)
+		}
+		padding := strings.Repeat(" ", width-utf8.RuneCountInString(moveNames[i]))
+		fmt.Printf("  %-4s %s%s  %s\n", level, moveNames[i], padding, entry.method)
+	}
+	return nil
+}
+
+func commandLearns(cfg *config, args []string) error {
+	move, err := fetchMove(pokeAPIBaseURL+"move/"+resourceName(args[0]), cfg.Cache)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(move.LearnedByPokemon))
+	for i, pokemon := range move.LearnedByPokemon {
+		names[i] = pokemon.Name
+	}
+	sort.Strings(names)
+	if len(names) == 0 && cfg.PipeOutput == nil {
+		fmt.Printf("No Pokemon can learn %s\n", move.Name)
+		return nil
+	}
+	cfg.emitLocalized(fmt.Sprintf("%d Pokemon can learn %s:", len(names), cfg.localize("move", move.Name)), "pokemon", names)
+	return nil
+}
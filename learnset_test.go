@@ -0,0 +1,97 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+const learnsetFixture = `{
+	"name": "pikachu",
+	"moves": [
+		{"move": {"name": "thunderbolt"}, "version_group_details": [
+			{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+		]},
+		{"move": {"name": "thunder-shock"}, "version_group_details": [
+			{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}},
+			{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "scarlet-violet", "url": "https://pokeapi.co/api/v2/version-group/25/"}}
+		]},
+		{"move": {"name": "growl"}, "version_group_details": [
+			{"level_learned_at": 1, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+		]},
+		{"move": {"name": "thunder-wave"}, "version_group_details": [
+			{"level_learned_at": 9, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+		]},
+		{"move": {"name": "volt-tackle"}, "version_group_details": [
+			{"level_learned_at": 0, "move_learn_method": {"name": "light-ball-egg"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+		]}
+	]
+}`
+
+func learnsetPokemon(t *testing.T) Pokemon {
+	var res pokemonDetailResponse
+	if err := json.Unmarshal([]byte(learnsetFixture), &res); err != nil {
+		t.Fatalf("Invalid fixture: %v", err)
+	}
+	return convertToPokemon(&res)
+}
+
+func TestLearnset(t *testing.T) {
+	pokemon := learnsetPokemon(t)
+
+	tests := []struct {
+		game     string
+		method   string
+		expected []string
+	}{
+		{game: "red-blue", expected: []string{"growl", "thunder-shock", "thunder-wave", "thunderbolt", "volt-tackle"}},
+		{game: "red-blue", method: "level-up", expected: []string{"growl", "thunder-shock", "thunder-wave"}},
+		{game: "scarlet-violet", expected: []string{"thunder-shock"}},
+		{game: "gold-silver", expected: nil},
+	}
+
+	for _, test := range tests {
+		entries := learnset(pokemon, test.game, test.method)
+		actual := make([]string, len(entries))
+		for i, entry := range entries {
+			actual[i] = entry.move
+		}
+		if len(actual) != len(test.expected) {
+			t.Errorf("Game: %s, method: %q - Expected %v, got %v", test.game, test.method, test.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != test.expected[i] {
+				t.Errorf("Game: %s, method: %q - Expected %v, got %v", test.game, test.method, test.expected, actual)
+				break
+			}
+		}
+	}
+}
+
+func TestVersionGroups(t *testing.T) {
+	groups := versionGroups(learnsetPokemon(t))
+	if len(groups) != 2 || groups[0] != "red-blue" || groups[1] != "scarlet-violet" {
+		t.Errorf("Expected [red-blue scarlet-violet], got %v", groups)
+	}
+}
+
+func TestCommandLearnsEmits(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"move/thunder-wave", []byte(`{
+		"name": "thunder-wave",
+		"learned_by_pokemon": [{"name": "raichu"}, {"name": "pikachu"}]
+	}`))
+
+	var output []string
+	cfg.PipeOutput = &output
+	if err := commandLearns(cfg, []string{"Thunder Wave"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(output) != 2 || output[0] != "pikachu" || output[1] != "raichu" {
+		t.Errorf("Expected [pikachu raichu], got %v", output)
+	}
+}
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// localizedEndpoints maps the kinds of names that can be localized to the
+// PokeAPI endpoint holding their names arrays. Pokemon names come from
+// their species.
+var localizedEndpoints = map[string]string{
+	"pokemon":       "pokemon-species",
+	"move":          "move",
+	"ability":       "ability",
+	"type":          "type",
+	"region":        "region",
+	"location":      "location",
+	"location-area": "location-area",
+}
+
+// localizedName is one entry of a PokeAPI names array.
+type localizedName struct {
+	Name     string        `json:"name"`
+	Language namedResource `json:"language"`
+}
+
+// namesResponse decodes only the names array of any resource.
+type namesResponse struct {
+	Name  string          `json:"name"`
+	Names []localizedName `json:"names"`
+}
+
+// language is the language code names are shown in, or "" to show the
+// identifiers PokeAPI uses.
+func (s *settings) language() string {
+	return s.Language
+}
+
+// pickLocalized returns the text for lang, falling back to English and
+// then to fallback. PokeAPI's language codes are matched case-insensitively
+// since some are mixed case, like ja-Hrkt.
+func pickLocalized(lang, fallback string, texts map[string]string) string {
+	for code, text := range texts {
+		if strings.EqualFold(code, lang) && text != "" {
+			return text
+		}
+	}
+	if text := texts["en"]; text != "" {
+		return text
+	}
+	return fallback
+}
+
+// localize returns the name of a resource in the chosen language. If no
+// language is chosen or the name can't be looked up, the identifier itself
+// is returned, and remembered so it isn't looked up again.
+func (cfg *config) localize(kind, slug string) string {
+	lang := cfg.Settings.language()
+	endpoint, ok := localizedEndpoints[kind]
+	if lang == "" || !ok || slug == "" {
+		return slug
+	}
+	key := kind + "/" + slug
+	if name, ok := cfg.LocalNames[key]; ok {
+		return name
+	}
+
+	texts := make(map[string]string)
+	if res, err := fetchResource[namesResponse](pokeAPIBaseURL+endpoint+"/"+slug, cfg.Cache); err == nil {
+		for _, n := range res.Names {
+			texts[n.Language.Name] = n.Name
+		}
+	}
+	name := pickLocalized(lang, slug, texts)
+
+	if cfg.LocalNames == nil {
+		cfg.LocalNames = make(map[string]string)
+	}
+	cfg.LocalNames[key] = name
+	return name
+}
+
+func (cfg *config) localizeAll(kind string, slugs []string) []string {
+	names := make([]string, len(slugs))
+	for i, slug := range slugs {
+		names[i] = cfg.localize(kind, slug)
+	}
+	return names
+}
+
+// emitLocalized is emit for resources of one kind: piped commands get the
+// identifiers, while people see the names in their language.
+func (cfg *config) emitLocalized(title, kind string, slugs []string) {
+	if cfg.PipeOutput != nil {
+		cfg.emit(title, slugs)
+		return
+	}
+	cfg.emit(title, cfg.localizeAll(kind, slugs))
+}
+
+// unlocalize finds the identifier of a name shown in the chosen language,
+// among the names localized so far and the candidates, which are
+// identifiers that input is likely to refer to. It returns "" if there is
+// no match.
+func (cfg *config) unlocalize(kind, input string, candidates []string) string {
+	input = strings.TrimSpace(input)
+	if cfg.Settings.language() == "" || input == "" {
+		return ""
+	}
+	prefix := kind + "/"
+	for key, name := range cfg.LocalNames {
+		if strings.HasPrefix(key, prefix) && strings.EqualFold(name, input) {
+			return strings.TrimPrefix(key, prefix)
+		}
+	}
+	for _, slug := range candidates {
+		if strings.EqualFold(cfg.localize(kind, slug), input) {
+			return slug
+		}
+	}
+	return ""
+}
+
+func commandLang(cfg *config, args []string) error {
+	if len(args) == 0 {
+		if lang := cfg.Settings.language(); lang != "" {
+			fmt.Printf("Language: %s\n", lang)
+		} else {
+			fmt.Println("Language: none, showing PokeAPI identifiers")
+		}
+		return nil
+	}
+
+	lang := strings.ToLower(args[0])
+	if lang == "none" || lang == "off" {
+		lang = ""
+	} else {
+		var err error
+		if lang, err = languageCode(cfg, args[0]); err != nil {
+			return err
+		}
+	}
+	if err := setLanguage(cfg, lang); err != nil {
+		return err
+	}
+	if lang == "" {
+		fmt.Println("Showing PokeAPI identifiers again")
+	} else {
+		fmt.Printf("Language set to %s\n", lang)
+	}
+	return nil
+}
+
+// languageCode finds the PokeAPI code of a language regardless of case, so
+// that "JA-HRKT" is stored as ja-Hrkt.
+func languageCode(cfg *config, input string) (string, error) {
+	res, err := fetchResourceList(pokeAPIBaseURL+"language?limit=100", cfg.Cache)
+	if err != nil {
+		return "", fmt.Errorf("could not load languages: %w", err)
+	}
+	for _, language := range res.Results {
+		if strings.EqualFold(language.Name, input) {
+			return language.Name, nil
+		}
+	}
+	return "", fmt.Errorf("unknown language %q", input)
+}
+
+// setLanguage changes and saves the language, forgetting names looked up
+// in the previous one.
+func setLanguage(cfg *config, lang string) error {
+	cfg.Settings.Language = lang
+	cfg.LocalNames = nil
+	if err := cfg.Settings.save(); err != nil {
+		return fmt.Errorf("could not save language: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+func TestPickLocalized(t *testing.T) {
+	texts := map[string]string{"en": "Pikachu", "ja-Hrkt": "ピカチュウ", "de": ""}
+
+	tests := []struct {
+		lang     string
+		expected string
+	}{
+		{lang: "ja-hrkt", expected: "ピカチュウ"},
+		{lang: "de", expected: "Pikachu"},
+		{lang: "fr", expected: "Pikachu"},
+		{lang: "", expected: "Pikachu"},
+	}
+
+	for _, test := range tests {
+		if actual := pickLocalized(test.lang, "pikachu", texts); actual != test.expected {
+			t.Errorf("Lang: %q - Expected %s, got %s", test.lang, test.expected, actual)
+		}
+	}
+	if actual := pickLocalized("de", "pikachu", nil); actual != "pikachu" {
+		t.Errorf("Expected to fall back to the identifier, got %s", actual)
+	}
+}
+
+func localizeTestConfig(t *testing.T) *config {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon-species/bulbasaur", []byte(`{
+		"name": "bulbasaur",
+		"names": [{"name": "Bulbasaur", "language": {"name": "en"}}, {"name": "Bisasam", "language": {"name": "de"}}]
+	}`))
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon-species/mr-mime", []byte(`{
+		"name": "mr-mime",
+		"names": [{"name": "Mr. Mime", "language": {"name": "en"}}, {"name": "Pantimos", "language": {"name": "de"}}]
+	}`))
+	return cfg
+}
+
+func TestLocalize(t *testing.T) {
+	cfg := localizeTestConfig(t)
+
+	if name := cfg.localize("pokemon", "bulbasaur"); name != "bulbasaur" {
+		t.Errorf("Expected identifiers without a language, got %s", name)
+	}
+
+	cfg.Settings.Language = "de"
+	if name := cfg.localize("pokemon", "bulbasaur"); name != "Bisasam" {
+		t.Errorf("Expected Bisasam, got %s", name)
+	}
+	if name := cfg.localize("pokemon", "missingno"); name != "missingno" {
+		t.Errorf("Expected unknown names to stay identifiers, got %s", name)
+	}
+	if name, ok := cfg.LocalNames["pokemon/missingno"]; !ok || name != "missingno" {
+		t.Errorf("Expected the fallback to be remembered, got %q", name)
+	}
+
+	var output []string
+	cfg.PipeOutput = &output
+	cfg.emitLocalized("", "pokemon", []string{"bulbasaur"})
+	if len(output) != 1 || output[0] != "bulbasaur" {
+		t.Errorf("Expected pipes to get identifiers, got %v", output)
+	}
+}
+
+func TestUnlocalize(t *testing.T) {
+	cfg := localizeTestConfig(t)
+	if slug := cfg.unlocalize("pokemon", "Bisasam", []string{"bulbasaur"}); slug != "" {
+		t.Errorf("Expected no lookups without a language, got %s", slug)
+	}
+
+	cfg.Settings.Language = "de"
+	if slug := cfg.unlocalize("pokemon", "pantimos", []string{"bulbasaur", "mr-mime"}); slug != "mr-mime" {
+		t.Errorf("Expected mr-mime, got %q", slug)
+	}
+	// Names shown before are found without candidates.
+	cfg.localize("pokemon", "bulbasaur")
+	if slug := cfg.unlocalize("pokemon", "Bisasam", nil); slug != "bulbasaur" {
+		t.Errorf("Expected bulbasaur, got %q", slug)
+	}
+	if slug := cfg.unlocalize("pokemon", "Glurak", []string{"bulbasaur"}); slug != "" {
+		t.Errorf("Expected no match, got %q", slug)
+	}
+}
+
+func TestLabelLocalized(t *testing.T) {
+	cfg := localizeTestConfig(t)
+	cfg.Settings.Language = "de"
+	owned := &OwnedPokemon{ID: 3, Species: "bulbasaur", Level: 5}
+	if label := owned.label(cfg); label != "#3 Bisasam Lv. 5" {
+		t.Errorf("Expected #3 Bisasam Lv. 5, got %s", label)
+	}
+	owned.Nickname = "Bulby"
+	if name := owned.displayName(cfg); name != "Bulby" {
+		t.Errorf("Expected the nickname, got %s", name)
+	}
+	if label := owned.label(cfg); label != "#3 Bulby (Bisasam) Lv. 5" {
+		t.Errorf("Expected #3 Bulby (Bisasam) Lv. 5, got %s", label)
+	}
+}
+
+func TestLanguageCode(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"language?limit=100", []byte(`{"results": [{"name": "de"}, {"name": "ja-Hrkt"}]}`))
+
+	tests := map[string]string{"DE": "de", "ja-hrkt": "ja-Hrkt", "JA-HRKT": "ja-Hrkt"}
+	for input, expected := range tests {
+		if code, err := languageCode(cfg, input); err != nil || code != expected {
+			t.Errorf("Input: %q - Expected %s, got %q (%v)", input, expected, code, err)
+		}
+	}
+	if _, err := languageCode(cfg, "xx"); err == nil {
+		t.Error("Expected an error for an unknown language")
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"bufio"
+	"flag"
 	"fmt"
 	"os"
 	"time"
@@ -10,10 +11,52 @@ import (
 )
 
 func main() {
+	seed := flag.Int64("seed", 0, "seed for the random numbers, to reproduce a session (default random)")
+	logPath := flag.String("log", defaultSessionLogPath(), "file to record the session's seed and commands in, empty to disable")
+	lang := flag.String("lang", "", "language code to show names in, e.g. de or ja-Hrkt; remembered for later sessions")
+	flag.Parse()
+
+	userSettings, err := loadSettings(defaultSettingsPath())
+	if err != nil {
+		fmt.Println("Could not load settings:", err)
+	}
+
 	cfg := &config{
-		Cache:   pokecache.NewCache(5 * time.Second),
-		Pokedex: make(map[string]Pokemon),
+		Cache:     pokecache.NewCache(5 * time.Second),
+		Pokedex:   make(map[string]Pokemon),
+		Inventory: newGameInventory(),
+		SavePath:  defaultSavePath(),
+		Settings:  userSettings,
+	}
+	if err := loadGame(cfg); err != nil {
+		fmt.Println("Could not load saved game:", err)
 	}
+	if *lang != "" {
+		if err := setLanguage(cfg, *lang); err != nil {
+			fmt.Println(err)
+		}
+	}
+
+	sessionLog, err := openSessionLog(*logPath)
+	if err != nil {
+		fmt.Println("Could not open session log:", err)
+	} else if sessionLog != nil {
+		defer sessionLog.Close()
+		cfg.SessionLog = sessionLog
+	}
+	cfg.logf("session started")
+
+	isSeedSet := false
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == "seed" {
+			isSeedSet = true
+		}
+	})
+	if !isSeedSet {
+		*seed = randomSeed()
+	}
+	seedRand(cfg, *seed)
+
 	scanner := bufio.NewScanner(os.Stdin)
 	for {
 		fmt.Print("Pokedex > ")
@@ -21,18 +64,9 @@ func main() {
 			break
 		}
 		text := scanner.Text()
-		cleaned := cleanInput(text)
-		if len(cleaned) == 0 {
-			continue
-		}
-		input := cleaned[0]
-		if command, ok := commands[input]; ok {
-			err := command.callback(cfg, cleaned[1:])
-			if err != nil {
-				fmt.Println(err)
-			}
-		} else {
-			fmt.Println("Unknown command:", input)
+		cfg.logf("> %s", text)
+		if err := runLine(cfg, text); err != nil {
+			fmt.Println(err)
 		}
 	}
 }
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+const maxKnownMoves = 4
+
+// defaultMoves are the last four moves a Pokemon learns by level-up at or
+// below level in its most recent game, the moves a wild Pokemon knows.
+func defaultMoves(p Pokemon, level int) []string {
+	groups := versionGroups(p)
+	if len(groups) == 0 {
+		return nil
+	}
+	var moves []string
+	for _, entry := range learnset(p, groups[len(groups)-1], "level-up") {
+		if entry.level > level {
+			continue
+		}
+		if i := slices.Index(moves, entry.move); i >= 0 {
+			moves = slices.Delete(moves, i, i+1)
+		}
+		moves = append(moves, entry.move)
+	}
+	if len(moves) > maxKnownMoves {
+		moves = moves[len(moves)-maxKnownMoves:]
+	}
+	return moves
+}
+
+// canLearn checks a move against a Pokemon's learnset in any game.
+// Level-up moves need the level; machines, tutors and egg moves can be
+// taught at any time.
+func canLearn(p Pokemon, move string, level int) error {
+	for _, m := range p.Moves {
+		if m.Move.Name != move {
+			continue
+		}
+		lowest := 0
+		for _, detail := range m.VersionGroupDetails {
+			if detail.MoveLearnMethod.Name != "level-up" || detail.LevelLearnedAt <= level {
+				return nil
+			}
+			if lowest == 0 || detail.LevelLearnedAt < lowest {
+				lowest = detail.LevelLearnedAt
+			}
+		}
+		return fmt.Errorf("%s learns %s at level %d", p.Name, move, lowest)
+	}
+	return fmt.Errorf("%s can't learn %s", p.Name, move)
+}
+
+func knowsMoveOfType(cfg *config, owned *OwnedPokemon, typeName string) bool {
+	for _, name := range owned.Moves {
+		move, err := fetchMove(pokeAPIBaseURL+"move/"+name, cfg.Cache)
+		if err == nil && move.Type.Name == typeName {
+			return true
+		}
+	}
+	return false
+}
+
+func commandTeach(cfg *config, args []string) error {
+	owned, err := findOwned(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	move := resourceName(args[1])
+	if slices.Contains(owned.Moves, move) {
+		return fmt.Errorf("%s already knows %s", owned.displayName(cfg), cfg.localize("move", move))
+	}
+	if err := canLearn(cfg.Pokedex[owned.Species], move, owned.Level); err != nil {
+		return err
+	}
+
+	if value, ok := cfg.flag("replace"); ok {
+		old := resourceName(value)
+		i := slices.Index(owned.Moves, old)
+		if i < 0 {
+			return fmt.Errorf("%s doesn't know %s", owned.displayName(cfg), cfg.localize("move", old))
+		}
+		owned.Moves[i] = move
+		fmt.Printf("%s forgot %s and learned %s!\n", owned.displayName(cfg), cfg.localize("move", old), cfg.localize("move", move))
+	} else {
+		if len(owned.Moves) >= maxKnownMoves {
+			return fmt.Errorf("%s already knows %d moves (%s); forget one or use --replace",
+				owned.displayName(cfg), maxKnownMoves, strings.Join(cfg.localizeAll("move", owned.Moves), ", "))
+		}
+		owned.Moves = append(owned.Moves, move)
+		fmt.Printf("%s learned %s!\n", owned.displayName(cfg), cfg.localize("move", move))
+	}
+
+	if err := saveGame(cfg); err != nil {
+		return fmt.Errorf("could not save moves: %w", err)
+	}
+	return nil
+}
+
+func commandForget(cfg *config, args []string) error {
+	owned, err := findOwned(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	move := resourceName(args[1])
+	i := slices.Index(owned.Moves, move)
+	if i < 0 {
+		return fmt.Errorf("%s doesn't know %s", owned.displayName(cfg), cfg.localize("move", move))
+	}
+	owned.Moves = slices.Delete(owned.Moves, i, i+1)
+	fmt.Printf("%s forgot %s\n", owned.displayName(cfg), cfg.localize("move", move))
+
+	if err := saveGame(cfg); err != nil {
+		return fmt.Errorf("could not save moves: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
+	"testing"
+)
+
+// levelUpPokemon learns move-N at level N in red-blue, and thunderbolt by
+// machine.
+func levelUpPokemon(t *testing.T, levels ...int) Pokemon {
+	var moves []string
+	for _, level := range levels {
+		moves = append(moves, fmt.Sprintf(`{"move": {"name": "move-%d"}, "version_group_details": [
+			{"level_learned_at": %d, "move_learn_method": {"name": "level-up"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+		]}`, level, level))
+	}
+	moves = append(moves, `{"move": {"name": "thunderbolt"}, "version_group_details": [
+		{"level_learned_at": 0, "move_learn_method": {"name": "machine"}, "version_group": {"name": "red-blue", "url": "https://pokeapi.co/api/v2/version-group/1/"}}
+	]}`)
+
+	var res pokemonDetailResponse
+	fixture := `{"name": "pikachu", "moves": [` + strings.Join(moves, ",") + `]}`
+	if err := json.Unmarshal([]byte(fixture), &res); err != nil {
+		t.Fatalf("Invalid fixture: %v", err)
+	}
+	return convertToPokemon(&res)
+}
+
+func TestDefaultMoves(t *testing.T) {
+	pokemon := levelUpPokemon(t, 1, 5, 9, 13, 20, 26)
+
+	tests := []struct {
+		level    int
+		expected []string
+	}{
+		{level: 1, expected: []string{"move-1"}},
+		{level: 10, expected: []string{"move-1", "move-5", "move-9"}},
+		{level: 25, expected: []string{"move-5", "move-9", "move-13", "move-20"}},
+	}
+
+	for _, test := range tests {
+		if actual := defaultMoves(pokemon, test.level); !slices.Equal(actual, test.expected) {
+			t.Errorf("Level: %d - Expected %v, got %v", test.level, test.expected, actual)
+		}
+	}
+}
+
+func TestCanLearn(t *testing.T) {
+	pokemon := levelUpPokemon(t, 1, 20)
+
+	if err := canLearn(pokemon, "move-20", 19); err == nil {
+		t.Error("Expected a level-up move not to be learnable below its level")
+	}
+	if err := canLearn(pokemon, "move-20", 20); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if err := canLearn(pokemon, "thunderbolt", 1); err != nil {
+		t.Errorf("Expected machine moves to be learnable at any level, got %v", err)
+	}
+	if err := canLearn(pokemon, "surf", 100); err == nil {
+		t.Error("Expected moves outside the learnset not to be learnable")
+	}
+}
+
+func TestTeachAndForget(t *testing.T) {
+	cfg := newTestConfig(t)
+	owned := addOwned(cfg, levelUpPokemon(t, 1, 5, 9, 13), 20)
+	owned.Moves = defaultMoves(cfg.Pokedex["pikachu"], owned.Level)
+
+	if err := commandTeach(cfg, []string{"pikachu", "thunderbolt"}); err == nil {
+		t.Error("Expected teaching a fifth move to fail")
+	}
+
+	cfg.Flags = map[string]string{"replace": "move-1"}
+	if err := commandTeach(cfg, []string{"pikachu", "Thunderbolt"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	cfg.Flags = nil
+	expected := []string{"thunderbolt", "move-5", "move-9", "move-13"}
+	if !slices.Equal(owned.Moves, expected) {
+		t.Errorf("Expected %v, got %v", expected, owned.Moves)
+	}
+
+	if err := commandForget(cfg, []string{"pikachu", "move-5"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := commandForget(cfg, []string{"pikachu", "move-5"}); err == nil {
+		t.Error("Expected forgetting an unknown move to fail")
+	}
+	if err := commandTeach(cfg, []string{"pikachu", "move-1"}); err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if len(owned.Moves) != maxKnownMoves {
+		t.Errorf("Expected %d moves, got %v", maxKnownMoves, owned.Moves)
+	}
+}
@@ -0,0 +1,245 @@
+package main
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// defaultCatchLevel is the level of caught Pokemon when nothing else
+// decides it.
+const defaultCatchLevel = 5
+
+// OwnedPokemon is one caught Pokemon. Every catch creates a new one, so a
+// trainer can own several Pokemon of the same species; the species data
+// itself is stored once in the Pokedex under Species.
+type OwnedPokemon struct {
+	ID         int    `json:"id"`
+	Species    string `json:"species"`
+	Nickname   string `json:"nickname,omitempty"`
+	Level      int    `json:"level"`
+	Experience int    `json:"experience"`
+	// GrowthRate names the experience curve of the species. Saves from
+	// before it was stored look it up again when needed.
+	GrowthRate string `json:"growth_rate,omitempty"`
+	// IVs and EVs are keyed by stat name. Pokemon caught before they were
+	// tracked have none, which counts as 0.
+	IVs    map[string]int `json:"ivs,omitempty"`
+	EVs    map[string]int `json:"evs,omitempty"`
+	Nature string         `json:"nature,omitempty"`
+	Gender string         `json:"gender,omitempty"`
+	Shiny  bool           `json:"shiny,omitempty"`
+	// Form is the cosmetic form of Pokemon that have several, such as
+	// unown's letters.
+	Form string `json:"form,omitempty"`
+	// Moves are the up to four moves the Pokemon knows.
+	Moves    []string  `json:"moves,omitempty"`
+	CaughtAt time.Time `json:"caught_at"`
+	Location string    `json:"location,omitempty"`
+}
+
+// displayName is the nickname if there is one, and the species otherwise.
+func (o *OwnedPokemon) displayName(cfg *config) string {
+	if o.Nickname != "" {
+		return o.Nickname
+	}
+	return cfg.localize("pokemon", o.Species)
+}
+
+// label describes the Pokemon in lists, e.g.
+// "#3 Sparky (pikachu) Lv. 5, female, shiny".
+func (o *OwnedPokemon) label(cfg *config) string {
+	name := cfg.localize("pokemon", o.Species)
+	if o.Nickname != "" {
+		name = fmt.Sprintf("%s (%s)", o.Nickname, name)
+	}
+	label := fmt.Sprintf("#%d %s Lv. %d", o.ID, name, o.Level)
+	for _, trait := range o.traits() {
+		label += ", " + trait
+	}
+	return label
+}
+
+// traits are what sets the Pokemon apart from others of its species: its
+// gender, shininess and form.
+func (o *OwnedPokemon) traits() []string {
+	var traits []string
+	if o.Gender == "male" || o.Gender == "female" {
+		traits = append(traits, o.Gender)
+	}
+	if o.Shiny {
+		traits = append(traits, "shiny")
+	}
+	if o.Form != "" {
+		traits = append(traits, o.Form)
+	}
+	return traits
+}
+
+// addOwned registers a newly caught Pokemon: the species goes into the
+// Pokedex if it is new, and a fresh OwnedPokemon is added to the box.
+func addOwned(cfg *config, pokemon Pokemon, level int) *OwnedPokemon {
+	if _, ok := cfg.Pokedex[pokemon.Name]; !ok {
+		cfg.Pokedex[pokemon.Name] = pokemon
+	}
+	cfg.markSeen(pokemon.Name)
+
+	cfg.NextOwnedID++
+	owned := &OwnedPokemon{
+		ID:       cfg.NextOwnedID,
+		Species:  pokemon.Name,
+		Level:    level,
+		CaughtAt: time.Now(),
+		Location: cfg.Location,
+	}
+	cfg.Box = append(cfg.Box, owned)
+	return owned
+}
+
+func (cfg *config) markSeen(names ...string) {
+	if cfg.Seen == nil {
+		cfg.Seen = make(map[string]bool)
+	}
+	for _, name := range names {
+		cfg.Seen[name] = true
+	}
+}
+
+// findOwned resolves a reference to one of the trainer's Pokemon. The
+// reference is an ID ("3" or "#3"), a nickname, or a species name; a
+// species matches the first such Pokemon in the party, or else the one
+// caught first.
+func findOwned(cfg *config, ref string) (*OwnedPokemon, error) {
+	if id, err := strconv.Atoi(strings.TrimPrefix(ref, "#")); err == nil {
+		if owned := ownedByID(cfg, id); owned != nil {
+			return owned, nil
+		}
+		return nil, fmt.Errorf("you don't have a Pokemon with ID #%d", id)
+	}
+
+	for _, owned := range cfg.Box {
+		if owned.Nickname != "" && strings.EqualFold(owned.Nickname, ref) {
+			return owned, nil
+		}
+	}
+
+	species := resourceName(ref)
+	for _, id := range cfg.Party {
+		if owned := ownedByID(cfg, id); owned != nil && owned.Species == species {
+			return owned, nil
+		}
+	}
+	for _, owned := range cfg.Box {
+		if owned.Species == species {
+			return owned, nil
+		}
+	}
+	return nil, fmt.Errorf("you have not caught that pokemon: %s", ref)
+}
+
+func ownedByID(cfg *config, id int) *OwnedPokemon {
+	for _, owned := range cfg.Box {
+		if owned.ID == id {
+			return owned
+		}
+	}
+	return nil
+}
+
+// ownedSummary sums up the Pokemon of a species in the box for the
+// Pokedex, e.g. "3 owned, 2 male, 1 female, 1 shiny".
+func ownedSummary(cfg *config, species string) string {
+	owned := 0
+	counts := make(map[string]int)
+	var forms []string
+	for _, o := range cfg.Box {
+		if o.Species != species {
+			continue
+		}
+		owned++
+		if o.Shiny {
+			counts["shiny"]++
+		}
+		counts[o.Gender]++
+		if o.Form != "" && !slices.Contains(forms, o.Form) {
+			forms = append(forms, o.Form)
+		}
+	}
+
+	parts := []string{fmt.Sprintf("%d owned", owned)}
+	for _, trait := range []string{"male", "female", "shiny"} {
+		if counts[trait] > 0 {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[trait], trait))
+		}
+	}
+	if len(forms) > 0 {
+		sort.Strings(forms)
+		parts = append(parts, "forms "+strings.Join(forms, "/"))
+	}
+	return strings.Join(parts, ", ")
+}
+
+func commandBox(cfg *config, args []string) error {
+	_ = args
+	if len(cfg.Box) == 0 {
+		fmt.Println("Your box is empty. Catch some Pokemon first!")
+		return nil
+	}
+	fmt.Println("Your Pokemon:")
+	for _, owned := range cfg.Box {
+		line := "  " + owned.label(cfg)
+		if partySlot(cfg, owned.ID) >= 0 {
+			line += " [party]"
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+func commandNickname(cfg *config, args []string) error {
+	owned, err := findOwned(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	nickname := ""
+	if len(args) == 2 {
+		nickname = strings.TrimSpace(args[1])
+	}
+	if _, err := strconv.Atoi(strings.TrimPrefix(nickname, "#")); err == nil {
+		return fmt.Errorf("nicknames can't be numbers, they would look like IDs")
+	}
+	for _, other := range cfg.Box {
+		if other != owned && nickname != "" && strings.EqualFold(other.Nickname, nickname) {
+			return fmt.Errorf("#%d is already called %s", other.ID, other.Nickname)
+		}
+	}
+
+	owned.Nickname = nickname
+	if err := saveGame(cfg); err != nil {
+		return fmt.Errorf("could not save nickname: %w", err)
+	}
+	if nickname == "" {
+		fmt.Printf("#%d is now just called %s\n", owned.ID, cfg.localize("pokemon", owned.Species))
+	} else {
+		fmt.Printf("#%d %s is now called %s\n", owned.ID, cfg.localize("pokemon", owned.Species), nickname)
+	}
+	return nil
+}
+
+func sortedSpecies(cfg *config) []string {
+	names := make([]string, 0, len(cfg.Seen))
+	for name := range cfg.Seen {
+		names = append(names, name)
+	}
+	for name := range cfg.Pokedex {
+		if !cfg.Seen[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,116 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestAddOwnedKeepsDuplicates(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Location = "route-1-area"
+
+	first := addOwned(cfg, Pokemon{Name: "pidgey"}, 3)
+	second := addOwned(cfg, Pokemon{Name: "pidgey"}, 4)
+
+	if first.ID == second.ID {
+		t.Errorf("Expected distinct IDs, both got #%d", first.ID)
+	}
+	if len(cfg.Box) != 2 || len(cfg.Pokedex) != 1 {
+		t.Errorf("Expected 2 Pokemon in the box and 1 species in the Pokedex, got %d and %d",
+			len(cfg.Box), len(cfg.Pokedex))
+	}
+	if second.Level != 4 || second.Location != "route-1-area" || second.CaughtAt.IsZero() {
+		t.Errorf("Expected catch metadata to be recorded, got %+v", second)
+	}
+	if !cfg.Seen["pidgey"] {
+		t.Error("Expected a caught species to be marked as seen")
+	}
+}
+
+func TestFindOwned(t *testing.T) {
+	cfg := newTestConfig(t)
+	addOwned(cfg, Pokemon{Name: "pidgey"}, 3)
+	second := addOwned(cfg, Pokemon{Name: "pidgey"}, 4)
+	second.Nickname = "Sky"
+	addOwned(cfg, Pokemon{Name: "mr-mime"}, 5)
+
+	tests := []struct {
+		ref      string
+		expected int
+	}{
+		{ref: "1", expected: 1},
+		{ref: "#2", expected: 2},
+		{ref: "sky", expected: 2},
+		{ref: "pidgey", expected: 1},
+		{ref: "Mr Mime", expected: 3},
+		{ref: "#9", expected: 0},
+		{ref: "rattata", expected: 0},
+	}
+
+	for _, test := range tests {
+		owned, err := findOwned(cfg, test.ref)
+		if test.expected == 0 {
+			if err == nil {
+				t.Errorf("Ref: %q - Expected an error, got #%d", test.ref, owned.ID)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("Ref: %q - Unexpected error: %v", test.ref, err)
+			continue
+		}
+		if owned.ID != test.expected {
+			t.Errorf("Ref: %q - Expected #%d, got #%d", test.ref, test.expected, owned.ID)
+		}
+	}
+
+	// A species in the party is preferred over one caught earlier.
+	cfg.Party = []int{2}
+	if owned, _ := findOwned(cfg, "pidgey"); owned == nil || owned.ID != 2 {
+		t.Errorf("Expected pidgey to resolve to the party member #2, got %v", owned)
+	}
+}
+
+func TestNickname(t *testing.T) {
+	cfg := newTestConfig(t)
+	addOwned(cfg, Pokemon{Name: "pikachu"}, 5)
+	addOwned(cfg, Pokemon{Name: "pikachu"}, 5)
+
+	if err := commandNickname(cfg, []string{"#1", "Sir Sparks"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := commandNickname(cfg, []string{"#2", "sir sparks"}); err == nil {
+		t.Error("Expected a duplicate nickname to fail, got no error")
+	}
+	if err := commandNickname(cfg, []string{"#2", "42"}); err == nil {
+		t.Error("Expected a numeric nickname to fail, got no error")
+	}
+	if owned, err := findOwned(cfg, "Sir Sparks"); err != nil || owned.ID != 1 {
+		t.Errorf("Expected the nickname to find #1, got %v, %v", owned, err)
+	}
+	if err := commandNickname(cfg, []string{"Sir Sparks"}); err != nil || cfg.Box[0].Nickname != "" {
+		t.Errorf("Expected the nickname to be removed, got %q, %v", cfg.Box[0].Nickname, err)
+	}
+}
+
+func TestOwnedVariantsInListings(t *testing.T) {
+	cfg := newTestConfig(t)
+	first := addOwned(cfg, Pokemon{Name: "unown"}, 5)
+	first.Gender, first.Form = "genderless", "unown-f"
+	second := addOwned(cfg, Pokemon{Name: "unown"}, 7)
+	second.Gender, second.Form, second.Shiny = "genderless", "unown-a", true
+	third := addOwned(cfg, Pokemon{Name: "pikachu"}, 5)
+	third.Gender, third.Nickname = "female", "Sparky"
+
+	if label := second.label(cfg); label != "#2 unown Lv. 7, shiny, unown-a" {
+		t.Errorf("Expected #2 unown Lv. 7, shiny, unown-a, got %s", label)
+	}
+	if label := third.label(cfg); label != "#3 Sparky (pikachu) Lv. 5, female" {
+		t.Errorf("Expected #3 Sparky (pikachu) Lv. 5, female, got %s", label)
+	}
+	if summary := ownedSummary(cfg, "unown"); summary != "2 owned, 1 shiny, forms unown-a/unown-f" {
+		t.Errorf("Expected 2 owned, 1 shiny, forms unown-a/unown-f, got %s", summary)
+	}
+	if summary := ownedSummary(cfg, "pikachu"); summary != "1 owned, 1 female" {
+		t.Errorf("Expected 1 owned, 1 female, got %s", summary)
+	}
+}
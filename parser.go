@@ -1,12 +1,189 @@
 package main
 
 import (
+	"fmt"
 	"strings"
 )
 
-func cleanInput(text string) []string {
-	lowered := strings.ToLower(text)
-	words := strings.Fields(lowered)
+// cleanInput splits a line of input into words the way a shell would.
+// Words are separated by whitespace unless it is quoted with single or
+// double quotes or escaped with a backslash. Inside double quotes a
+// backslash escapes the next character; inside single quotes everything
+// is taken literally. Only the command name is lowercased so arguments
+// such as nicknames and file paths keep their case.
+func cleanInput(text string) ([]string, error) {
+	words, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	if len(words) > 0 {
+		words[0] = strings.ToLower(words[0])
+	}
+	return words, nil
+}
+
+func tokenize(text string) ([]string, error) {
+	var words []string
+	var current strings.Builder
+	inWord := false
+	var quote rune
+	escaped := false
+
+	for _, r := range text {
+		switch {
+		case escaped:
+			current.WriteRune(r)
+			escaped = false
+		case quote == '\'':
+			if r == '\'' {
+				quote = 0
+			} else {
+				current.WriteRune(r)
+			}
+		case quote == '"':
+			switch r {
+			case '"':
+				quote = 0
+			case '\\':
+				escaped = true
+			default:
+				current.WriteRune(r)
+			}
+		case r == '\'' || r == '"':
+			quote = r
+			inWord = true
+		case r == '\\':
+			escaped = true
+			inWord = true
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			if inWord {
+				words = append(words, current.String())
+				current.Reset()
+				inWord = false
+			}
+		default:
+			current.WriteRune(r)
+			inWord = true
+		}
+	}
+
+	if escaped {
+		return nil, fmt.Errorf("unfinished escape at end of input")
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inWord {
+		words = append(words, current.String())
+	}
+
+	return words, nil
+}
+
+// resourceName normalizes user input into a PokeAPI identifier, which are
+// lowercase and use hyphens instead of spaces (e.g. "Mr Mime" -> "mr-mime").
+func resourceName(s string) string {
+	s = strings.ToLower(strings.TrimSpace(s))
+	return strings.Join(strings.Fields(s), "-")
+}
+
+// splitUnquoted splits text on every sep that is not inside quotes or
+// escaped. The pieces keep their quoting so they can be passed to
+// cleanInput afterwards.
+func splitUnquoted(text string, sep rune) []string {
+	var parts []string
+	var current strings.Builder
+	var quote rune
+	escaped := false
+
+	for _, r := range text {
+		switch {
+		case escaped:
+			escaped = false
+		case r == '\\' && quote != '\'':
+			escaped = true
+		case quote != 0:
+			if r == quote {
+				quote = 0
+			}
+		case r == '\'' || r == '"':
+			quote = r
+		case r == sep:
+			parts = append(parts, current.String())
+			current.Reset()
+			continue
+		}
+		current.WriteRune(r)
+	}
+
+	return append(parts, current.String())
+}
+
+// joinWords is the inverse of cleanInput: it joins words with spaces,
+// quoting any word that would otherwise be split or unescaped differently.
+func joinWords(words []string) string {
+	quoted := make([]string, len(words))
+	for i, word := range words {
+		quoted[i] = quoteWord(word)
+	}
+	return strings.Join(quoted, " ")
+}
+
+func quoteWord(word string) string {
+	if word != "" && !strings.ContainsAny(word, " \t\n\r'\"\\") {
+		return word
+	}
+	if !strings.Contains(word, "'") {
+		return "'" + word + "'"
+	}
+	escaped := strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(word)
+	return `"` + escaped + `"`
+}
+
+// parseFlags separates the --options declared in specs from positional
+// arguments. Options are written "--name value" or "--name=value", or just
+// "--name" for boolean flags. A lone "--" ends option parsing.
+func parseFlags(specs []flagSpec, args []string) (map[string]string, []string, error) {
+	flags := make(map[string]string)
+	var positional []string
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if arg == "--" {
+			positional = append(positional, args[i+1:]...)
+			break
+		}
+		if !strings.HasPrefix(arg, "--") {
+			positional = append(positional, arg)
+			continue
+		}
+
+		name, value, hasValue := strings.Cut(strings.TrimPrefix(arg, "--"), "=")
+		name = strings.ToLower(name)
+		var spec *flagSpec
+		for j := range specs {
+			if specs[j].name == name {
+				spec = &specs[j]
+			}
+		}
+		if spec == nil {
+			return nil, nil, fmt.Errorf("unknown option --%s", name)
+		}
+
+		switch {
+		case spec.value == "" && hasValue:
+			return nil, nil, fmt.Errorf("option --%s does not take a value", name)
+		case spec.value == "":
+			value = "true"
+		case !hasValue:
+			if i+1 >= len(args) {
+				return nil, nil, fmt.Errorf("option --%s requires a %s", name, spec.value)
+			}
+			i++
+			value = args[i]
+		}
+		flags[name] = value
+	}
 
-	return words
+	return flags, positional, nil
 }
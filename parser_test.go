@@ -15,7 +15,7 @@ func TestCleanInput(t *testing.T) {
 		},
 		{
 			input:    "HELLO WORLD",
-			expected: []string{"hello", "world"},
+			expected: []string{"hello", "WORLD"},
 		},
 		{
 			input:    "hello   world",
@@ -23,16 +23,52 @@ func TestCleanInput(t *testing.T) {
 		},
 		{
 			input:    "hEllo woRld",
-			expected: []string{"hello", "world"},
+			expected: []string{"hello", "woRld"},
 		},
 		{
 			input:    "hello WORLD",
-			expected: []string{"hello", "world"},
+			expected: []string{"hello", "WORLD"},
+		},
+		{
+			input:    "  \t ",
+			expected: []string{},
+		},
+		{
+			input:    `nickname pikachu "Sir Sparks"`,
+			expected: []string{"nickname", "pikachu", "Sir Sparks"},
+		},
+		{
+			input:    `export 'My Documents/dex.json'`,
+			expected: []string{"export", "My Documents/dex.json"},
+		},
+		{
+			input:    `say "she said \"hi\""`,
+			expected: []string{"say", `she said "hi"`},
+		},
+		{
+			input:    `say 'no \escapes here'`,
+			expected: []string{"say", `no \escapes here`},
+		},
+		{
+			input:    `say one\ word`,
+			expected: []string{"say", "one word"},
+		},
+		{
+			input:    `say "" ''`,
+			expected: []string{"say", "", ""},
+		},
+		{
+			input:    `say pre"mid dle"post`,
+			expected: []string{"say", "premid dlepost"},
 		},
 	}
 
 	for _, test := range tests {
-		actual := cleanInput(test.input)
+		actual, err := cleanInput(test.input)
+		if err != nil {
+			t.Errorf("Input: %q - Unexpected error: %v", test.input, err)
+			continue
+		}
 		if len(actual) != len(test.expected) {
 			t.Errorf("Input: %q - Expected length %d (%v), got length %d (%v)",
 				test.input, len(test.expected), test.expected, len(actual), actual)
@@ -46,3 +82,87 @@ func TestCleanInput(t *testing.T) {
 		}
 	}
 }
+
+func TestCleanInputErrors(t *testing.T) {
+	tests := []string{
+		`say "unterminated`,
+		`say 'unterminated`,
+		`say trailing\`,
+	}
+
+	for _, input := range tests {
+		if _, err := cleanInput(input); err == nil {
+			t.Errorf("Input: %q - Expected an error, got none", input)
+		}
+	}
+}
+
+func TestResourceName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{input: "Pikachu", expected: "pikachu"},
+		{input: "Mr Mime", expected: "mr-mime"},
+		{input: "  canalave-city-area ", expected: "canalave-city-area"},
+	}
+
+	for _, test := range tests {
+		if actual := resourceName(test.input); actual != test.expected {
+			t.Errorf("Input: %q - Expected %q, got %q", test.input, test.expected, actual)
+		}
+	}
+}
+
+func TestSplitUnquoted(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected []string
+	}{
+		{input: "explore $1; catch $2", expected: []string{"explore $1", " catch $2"}},
+		{input: `say "a;b"; say 'c;d'`, expected: []string{`say "a;b"`, ` say 'c;d'`}},
+		{input: `say a\;b`, expected: []string{`say a\;b`}},
+		{input: "", expected: []string{""}},
+	}
+
+	for _, test := range tests {
+		actual := splitUnquoted(test.input, ';')
+		if len(actual) != len(test.expected) {
+			t.Errorf("Input: %q - Expected %q, got %q", test.input, test.expected, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != test.expected[i] {
+				t.Errorf("Input: %q - At index %d: Expected %q, got %q",
+					test.input, i, test.expected[i], actual[i])
+			}
+		}
+	}
+}
+
+func TestJoinWordsRoundTrip(t *testing.T) {
+	tests := [][]string{
+		{"catch", "pikachu"},
+		{"nickname", "1", "Sir Sparks"},
+		{"say", "it's", `a "quote"`, `back\slash`, ""},
+	}
+
+	for _, words := range tests {
+		joined := joinWords(words)
+		actual, err := tokenize(joined)
+		if err != nil {
+			t.Errorf("Words: %q - Joined %q failed to parse: %v", words, joined, err)
+			continue
+		}
+		if len(actual) != len(words) {
+			t.Errorf("Words: %q - Joined %q parsed as %q", words, joined, actual)
+			continue
+		}
+		for i := range actual {
+			if actual[i] != words[i] {
+				t.Errorf("Words: %q - Joined %q parsed as %q", words, joined, actual)
+				break
+			}
+		}
+	}
+}
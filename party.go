@@ -0,0 +1,202 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxPartySize is the number of Pokemon that fit in the party.
+const maxPartySize = 6
+
+func commandParty(cfg *config, args []string) error {
+	if len(args) == 0 {
+		return partyList(cfg)
+	}
+
+	action, rest := strings.ToLower(args[0]), args[1:]
+	switch action {
+	case "list":
+		return partyList(cfg)
+	case "add":
+		if len(rest) != 1 {
+			return fmt.Errorf("usage: party add <pokemon>")
+		}
+		return partyAdd(cfg, rest[0])
+	case "remove":
+		if len(rest) != 1 {
+			return fmt.Errorf("usage: party remove <pokemon|slot>")
+		}
+		return partyRemove(cfg, rest[0])
+	case "swap":
+		if len(rest) != 2 {
+			return fmt.Errorf("usage: party swap <slot> <slot>")
+		}
+		return partySwap(cfg, rest[0], rest[1])
+	case "analyze":
+		return partyAnalyze(cfg)
+	default:
+		return fmt.Errorf("unknown party action: %s", action)
+	}
+}
+
+func partyList(cfg *config) error {
+	if len(cfg.Party) == 0 {
+		fmt.Println("Your party is empty. Add Pokemon with 'party add <pokemon>'.")
+		return nil
+	}
+	fmt.Println("Your party:")
+	for i, owned := range partyMembers(cfg) {
+		types := cfg.localizeAll("type", pokemonTypes(cfg.Pokedex[owned.Species]))
+		fmt.Printf("  %d. %s (%s)\n", i+1, owned.label(cfg), strings.Join(types, "/"))
+	}
+	return nil
+}
+
+func partyAdd(cfg *config, ref string) error {
+	owned, err := findOwned(cfg, ref)
+	if err != nil {
+		return err
+	}
+	if partySlot(cfg, owned.ID) >= 0 {
+		return fmt.Errorf("%s is already in your party", owned.displayName(cfg))
+	}
+	if len(cfg.Party) >= maxPartySize {
+		return fmt.Errorf("your party is full, remove a Pokemon first")
+	}
+
+	cfg.Party = append(cfg.Party, owned.ID)
+	if err := saveGame(cfg); err != nil {
+		return fmt.Errorf("could not save party: %w", err)
+	}
+	fmt.Printf("%s joined your party in slot %d\n", owned.displayName(cfg), len(cfg.Party))
+	return nil
+}
+
+func partyRemove(cfg *config, ref string) error {
+	slot, err := resolvePartySlot(cfg, ref)
+	if err != nil {
+		return err
+	}
+
+	owned := ownedByID(cfg, cfg.Party[slot])
+	cfg.Party = append(cfg.Party[:slot], cfg.Party[slot+1:]...)
+	if err := saveGame(cfg); err != nil {
+		return fmt.Errorf("could not save party: %w", err)
+	}
+	fmt.Printf("%s left your party and went back to the box\n", owned.displayName(cfg))
+	return nil
+}
+
+func partySwap(cfg *config, refA, refB string) error {
+	a, err := resolvePartySlot(cfg, refA)
+	if err != nil {
+		return err
+	}
+	b, err := resolvePartySlot(cfg, refB)
+	if err != nil {
+		return err
+	}
+
+	cfg.Party[a], cfg.Party[b] = cfg.Party[b], cfg.Party[a]
+	if err := saveGame(cfg); err != nil {
+		return fmt.Errorf("could not save party: %w", err)
+	}
+	fmt.Printf("Swapped %s and %s\n", ownedByID(cfg, cfg.Party[b]).displayName(cfg), ownedByID(cfg, cfg.Party[a]).displayName(cfg))
+	return nil
+}
+
+// partyAnalyze shows how the party as a whole fares against each attacking
+// type, to spot weaknesses shared by several members.
+func partyAnalyze(cfg *config) error {
+	if len(cfg.Party) == 0 {
+		return fmt.Errorf("your party is empty")
+	}
+
+	members := partyMembers(cfg)
+	var allTypes []string
+	for _, owned := range members {
+		allTypes = append(allTypes, pokemonTypes(cfg.Pokedex[owned.Species])...)
+	}
+	if err := loadTypeChart(cfg, allTypes...); err != nil {
+		return err
+	}
+
+	type coverage struct {
+		attacking string
+		weak      []string
+		resistant []string
+	}
+	var table []coverage
+	for _, attacking := range cfg.TypeChart.attackingTypes(allTypes) {
+		c := coverage{attacking: attacking}
+		for _, owned := range members {
+			m := cfg.TypeChart.multiplier(attacking, pokemonTypes(cfg.Pokedex[owned.Species]))
+			if m > 1 {
+				c.weak = append(c.weak, owned.displayName(cfg))
+			} else if m < 1 {
+				c.resistant = append(c.resistant, owned.displayName(cfg))
+			}
+		}
+		if len(c.weak) > 0 || len(c.resistant) > 0 {
+			table = append(table, c)
+		}
+	}
+	sort.SliceStable(table, func(i, j int) bool {
+		return len(table[i].weak)-len(table[i].resistant) > len(table[j].weak)-len(table[j].resistant)
+	})
+
+	fmt.Println("Party type coverage:")
+	for _, c := range table {
+		line := fmt.Sprintf("  %s: %d weak, %d resist", cfg.localize("type", c.attacking), len(c.weak), len(c.resistant))
+		if len(c.weak) > 0 {
+			line += " (weak: " + strings.Join(c.weak, ", ") + ")"
+		}
+		if len(c.weak) > 1 && len(c.weak) > len(c.resistant) {
+			line += " <- shared weakness"
+		}
+		fmt.Println(line)
+	}
+	return nil
+}
+
+// resolvePartySlot turns a 1-based slot number or a reference to a
+// Pokemon in the party into an index into cfg.Party.
+func resolvePartySlot(cfg *config, ref string) (int, error) {
+	if n, err := strconv.Atoi(ref); err == nil {
+		if n < 1 || n > len(cfg.Party) {
+			return 0, fmt.Errorf("no Pokemon in party slot %d", n)
+		}
+		return n - 1, nil
+	}
+	owned, err := findOwned(cfg, ref)
+	if err != nil {
+		return 0, err
+	}
+	slot := partySlot(cfg, owned.ID)
+	if slot < 0 {
+		return 0, fmt.Errorf("%s is not in your party", owned.displayName(cfg))
+	}
+	return slot, nil
+}
+
+func partySlot(cfg *config, id int) int {
+	for i, member := range cfg.Party {
+		if member == id {
+			return i
+		}
+	}
+	return -1
+}
+
+// partyMembers returns the Pokemon in the party, in slot order.
+func partyMembers(cfg *config) []*OwnedPokemon {
+	members := make([]*OwnedPokemon, 0, len(cfg.Party))
+	for _, id := range cfg.Party {
+		if owned := ownedByID(cfg, id); owned != nil {
+			members = append(members, owned)
+		}
+	}
+	return members
+}
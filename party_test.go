@@ -0,0 +1,92 @@
+package main
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestPartyAddRemoveSwap(t *testing.T) {
+	cfg := newTestConfig(t)
+	for _, name := range []string{"pikachu", "geodude", "zubat"} {
+		addOwned(cfg, Pokemon{Name: name}, defaultCatchLevel)
+	}
+
+	steps := []struct {
+		args     []string
+		expected []int
+		fails    bool
+	}{
+		{args: []string{"add", "pikachu"}, expected: []int{1}},
+		{args: []string{"add", "Geodude"}, expected: []int{1, 2}},
+		{args: []string{"add", "#3"}, expected: []int{1, 2, 3}},
+		{args: []string{"add", "pikachu"}, expected: []int{1, 2, 3}, fails: true},
+		{args: []string{"add", "mewtwo"}, expected: []int{1, 2, 3}, fails: true},
+		{args: []string{"swap", "1", "zubat"}, expected: []int{3, 2, 1}},
+		{args: []string{"remove", "2"}, expected: []int{3, 1}},
+		{args: []string{"remove", "geodude"}, expected: []int{3, 1}, fails: true},
+		{args: []string{"swap", "1", "3"}, expected: []int{3, 1}, fails: true},
+	}
+
+	for _, step := range steps {
+		err := commandParty(cfg, step.args)
+		if (err != nil) != step.fails {
+			t.Errorf("party %q - Expected failure=%v, got error %v", step.args, step.fails, err)
+		}
+		if !reflect.DeepEqual(cfg.Party, step.expected) {
+			t.Errorf("party %q - Expected party %v, got %v", step.args, step.expected, cfg.Party)
+		}
+	}
+}
+
+func TestPartyIsFull(t *testing.T) {
+	cfg := newTestConfig(t)
+	for i := 0; i <= maxPartySize; i++ {
+		owned := addOwned(cfg, Pokemon{Name: "magikarp"}, defaultCatchLevel)
+		if i < maxPartySize {
+			cfg.Party = append(cfg.Party, owned.ID)
+		}
+	}
+
+	if err := commandParty(cfg, []string{"add", "#7"}); err == nil {
+		t.Error("Expected adding a seventh Pokemon to fail, got no error")
+	}
+}
+
+func TestSaveAndLoadGame(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.SavePath = filepath.Join(t.TempDir(), "save.json")
+	cfg.Location = "viridian-forest-area"
+	owned := addOwned(cfg, testPokemon("pikachu", []string{"electric"}, map[string]int{"hp": 35}), 7)
+	owned.Nickname = "Sparky"
+	cfg.markSeen("caterpie")
+	cfg.Party = []int{owned.ID}
+
+	if err := saveGame(cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	loaded := newTestConfig(t)
+	loaded.SavePath = cfg.SavePath
+	if err := loadGame(loaded); err != nil {
+		t.Fatal(err)
+	}
+
+	if !reflect.DeepEqual(loaded.Pokedex, cfg.Pokedex) {
+		t.Errorf("Expected Pokedex %v, got %v", cfg.Pokedex, loaded.Pokedex)
+	}
+	if !reflect.DeepEqual(loaded.Seen, cfg.Seen) {
+		t.Errorf("Expected seen %v, got %v", cfg.Seen, loaded.Seen)
+	}
+	if len(loaded.Box) != 1 || !loaded.Box[0].CaughtAt.Equal(owned.CaughtAt) {
+		t.Fatalf("Expected box %v, got %v", cfg.Box, loaded.Box)
+	}
+	loaded.Box[0].CaughtAt = owned.CaughtAt
+	if !reflect.DeepEqual(loaded.Box, cfg.Box) {
+		t.Errorf("Expected box %v, got %v", cfg.Box, loaded.Box)
+	}
+	if loaded.NextOwnedID != cfg.NextOwnedID || !reflect.DeepEqual(loaded.Party, cfg.Party) {
+		t.Errorf("Expected party %v and next ID %d, got %v and %d",
+			cfg.Party, cfg.NextOwnedID, loaded.Party, loaded.NextOwnedID)
+	}
+}
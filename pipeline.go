@@ -0,0 +1,121 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// emit outputs a list of resource names. When the command's output is
+// piped the names go to the next command; otherwise they are printed, as
+// an indented list under title or one per line if title is empty.
+func (cfg *config) emit(title string, names []string) {
+	if cfg.PipeOutput != nil {
+		*cfg.PipeOutput = append(*cfg.PipeOutput, names...)
+		return
+	}
+	if title == "" {
+		for _, name := range names {
+			fmt.Println(name)
+		}
+		return
+	}
+	fmt.Println(title)
+	for _, name := range names {
+		fmt.Printf("  - %s\n", name)
+	}
+}
+
+// forward passes resource names to the next command in a pipeline, for
+// commands whose results are already described by what they print.
+func (cfg *config) forward(names ...string) {
+	if cfg.PipeOutput != nil {
+		*cfg.PipeOutput = append(*cfg.PipeOutput, names...)
+	}
+}
+
+type filterCondition struct {
+	key    string
+	value  string
+	negate bool
+}
+
+func commandFilter(cfg *config, args []string) error {
+	if cfg.PipeInput == nil {
+		return fmt.Errorf("filter reads Pokemon from a pipeline, e.g. explore <area> | filter type=rock")
+	}
+
+	conditions := make([]filterCondition, len(args))
+	for i, arg := range args {
+		condition, err := parseFilterCondition(arg)
+		if err != nil {
+			return err
+		}
+		conditions[i] = condition
+	}
+
+	var kept []string
+	for _, name := range cfg.PipeInput {
+		ok, err := matchesFilter(cfg, name, conditions)
+		if err != nil {
+			return err
+		}
+		if ok {
+			kept = append(kept, name)
+		}
+	}
+
+	cfg.emit("Matching Pokemon:", kept)
+	return nil
+}
+
+func parseFilterCondition(arg string) (filterCondition, error) {
+	var condition filterCondition
+	key, value, found := strings.Cut(arg, "=")
+	if !found {
+		return condition, fmt.Errorf("invalid filter condition %q, expected key=value", arg)
+	}
+	if strings.HasSuffix(key, "!") {
+		key = strings.TrimSuffix(key, "!")
+		condition.negate = true
+	}
+	condition.key = strings.ToLower(key)
+	condition.value = strings.ToLower(value)
+
+	switch condition.key {
+	case "name", "type":
+	case "caught":
+		if condition.value != "yes" && condition.value != "no" {
+			return condition, fmt.Errorf("caught filter expects yes or no, got %q", value)
+		}
+	default:
+		return condition, fmt.Errorf("unknown filter key %q", key)
+	}
+	return condition, nil
+}
+
+func matchesFilter(cfg *config, name string, conditions []filterCondition) (bool, error) {
+	for _, condition := range conditions {
+		var match bool
+		switch condition.key {
+		case "name":
+			match = strings.Contains(name, condition.value)
+		case "caught":
+			_, caught := cfg.Pokedex[name]
+			match = caught == (condition.value == "yes")
+		case "type":
+			pokemon, err := fetchPokemon(pokeAPIBaseURL+"pokemon/"+name, cfg.Cache)
+			if err != nil {
+				return false, fmt.Errorf("could not look up %s: %w", name, err)
+			}
+			for _, t := range pokemon.Types {
+				if t.Type.Name == condition.value {
+					match = true
+				}
+			}
+		}
+		if match == condition.negate {
+			return false, nil
+		}
+	}
+	return true, nil
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+// emitCommand registers a command that emits the given names.
+func emitCommand(t *testing.T, name string, names ...string) {
+	t.Helper()
+	commands[name] = cliCommand{
+		name: name,
+		callback: func(cfg *config, args []string) error {
+			cfg.emit("", names)
+			return nil
+		},
+	}
+	t.Cleanup(func() { delete(commands, name) })
+}
+
+func TestRunLinePipeline(t *testing.T) {
+	cfg := newTestConfig(t)
+	emitCommand(t, "source", "pikachu", "geodude", "onix")
+	calls := recordCommand(t, "record")
+
+	if err := runLine(cfg, "source | filter name!=chu | record --flag"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{{"--flag", "geodude"}, {"--flag", "onix"}}
+	if !reflect.DeepEqual(*calls, expected) {
+		t.Errorf("Expected calls %q, got %q", expected, *calls)
+	}
+	if cfg.PipeInput != nil || cfg.PipeOutput != nil {
+		t.Error("Expected pipe state to be reset after the pipeline")
+	}
+}
+
+func TestRunLinePipelineInMacro(t *testing.T) {
+	cfg := newTestConfig(t)
+	emitCommand(t, "source", "pikachu", "geodude")
+	calls := recordCommand(t, "record")
+	cfg.Settings.Macros["rocks"] = "source | filter name=$1"
+
+	if err := runLine(cfg, "rocks geo | record"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{{"geodude"}}
+	if !reflect.DeepEqual(*calls, expected) {
+		t.Errorf("Expected calls %q, got %q", expected, *calls)
+	}
+}
+
+func TestRunLineEmptyStage(t *testing.T) {
+	cfg := newTestConfig(t)
+	if err := runLine(cfg, "pokedex | | catch"); err == nil {
+		t.Error("Expected an error for an empty pipeline stage, got none")
+	}
+}
+
+func TestFilterByTypeAndCaught(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon/geodude",
+		[]byte(`{"name": "geodude", "types": [{"slot": 1, "type": {"name": "rock"}}, {"slot": 2, "type": {"name": "ground"}}]}`))
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon/zubat",
+		[]byte(`{"name": "zubat", "types": [{"slot": 1, "type": {"name": "poison"}}, {"slot": 2, "type": {"name": "flying"}}]}`))
+	cfg.Pokedex["zubat"] = Pokemon{Name: "zubat"}
+
+	tests := []struct {
+		conditions []string
+		expected   []string
+	}{
+		{conditions: []string{"type=rock"}, expected: []string{"geodude"}},
+		{conditions: []string{"type!=rock"}, expected: []string{"zubat"}},
+		{conditions: []string{"caught=no"}, expected: []string{"geodude"}},
+		{conditions: []string{"type=flying", "caught=no"}, expected: []string{}},
+	}
+
+	for _, test := range tests {
+		output := []string{}
+		cfg.PipeInput = []string{"geodude", "zubat"}
+		cfg.PipeOutput = &output
+		if err := commandFilter(cfg, test.conditions); err != nil {
+			t.Errorf("Conditions: %q - Unexpected error: %v", test.conditions, err)
+			continue
+		}
+		if !reflect.DeepEqual(output, test.expected) {
+			t.Errorf("Conditions: %q - Expected %q, got %q", test.conditions, test.expected, output)
+		}
+	}
+}
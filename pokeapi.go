@@ -0,0 +1,282 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+const pokeAPIBaseURL = "https://pokeapi.co/api/v2/"
+
+// fetchData returns the raw response body for url, serving it from the
+// cache when possible and caching it otherwise.
+func fetchData(url string, cache *pokecache.Cache) ([]byte, error) {
+	if cachedData, ok := cache.Get(url); ok {
+		return cachedData, nil
+	}
+
+	req, err := http.NewRequest("GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "PokedexCLI")
+
+	client := &http.Client{}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+
+	cache.Add(url, body)
+
+	return body, nil
+}
+
+// fetchResource fetches url and decodes the JSON response into a T.
+func fetchResource[T any](url string, cache *pokecache.Cache) (*T, error) {
+	body, err := fetchData(url, cache)
+	if err != nil {
+		return nil, err
+	}
+
+	var resource T
+	err = json.Unmarshal(body, &resource)
+	if err != nil {
+		return nil, err
+	}
+
+	return &resource, nil
+}
+
+type moveResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Accuracy *int   `json:"accuracy"`
+	Power    *int   `json:"power"`
+	PP       int    `json:"pp"`
+	Priority int    `json:"priority"`
+	Type     struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"type"`
+	DamageClass struct {
+		Name string `json:"name"`
+		URL  string `json:"url"`
+	} `json:"damage_class"`
+	LearnedByPokemon []namedResource `json:"learned_by_pokemon"`
+}
+
+func fetchMove(url string, cache *pokecache.Cache) (*moveResponse, error) {
+	return fetchResource[moveResponse](url, cache)
+}
+
+type typeResponse struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	DamageRelations struct {
+		DoubleDamageFrom []namedResource `json:"double_damage_from"`
+		DoubleDamageTo   []namedResource `json:"double_damage_to"`
+		HalfDamageFrom   []namedResource `json:"half_damage_from"`
+		HalfDamageTo     []namedResource `json:"half_damage_to"`
+		NoDamageFrom     []namedResource `json:"no_damage_from"`
+		NoDamageTo       []namedResource `json:"no_damage_to"`
+	} `json:"damage_relations"`
+}
+
+// namedResource is PokeAPI's reference to another resource.
+type namedResource struct {
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+// resourceListResponse is a page of one of PokeAPI's resource lists.
+type resourceListResponse struct {
+	Count    int             `json:"count"`
+	Next     *string         `json:"next"`
+	Previous *string         `json:"previous"`
+	Results  []namedResource `json:"results"`
+}
+
+func fetchResourceList(url string, cache *pokecache.Cache) (*resourceListResponse, error) {
+	return fetchResource[resourceListResponse](url, cache)
+}
+
+func fetchType(url string, cache *pokecache.Cache) (*typeResponse, error) {
+	return fetchResource[typeResponse](url, cache)
+}
+
+type itemResponse struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Names []struct {
+		Name     string        `json:"name"`
+		Language namedResource `json:"language"`
+	} `json:"names"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    namedResource `json:"language"`
+	} `json:"effect_entries"`
+}
+
+func fetchItem(url string, cache *pokecache.Cache) (*itemResponse, error) {
+	return fetchResource[itemResponse](url, cache)
+}
+
+type speciesResponse struct {
+	ID                 int            `json:"id"`
+	Name               string         `json:"name"`
+	CaptureRate        int            `json:"capture_rate"`
+	GenderRate         int            `json:"gender_rate"`
+	GrowthRate         namedResource  `json:"growth_rate"`
+	EvolvesFromSpecies *namedResource `json:"evolves_from_species"`
+	EvolutionChain     struct {
+		URL string `json:"url"`
+	} `json:"evolution_chain"`
+	Varieties []struct {
+		IsDefault bool          `json:"is_default"`
+		Pokemon   namedResource `json:"pokemon"`
+	} `json:"varieties"`
+	Genera []struct {
+		Genus    string        `json:"genus"`
+		Language namedResource `json:"language"`
+	} `json:"genera"`
+	FlavorTextEntries []struct {
+		FlavorText string        `json:"flavor_text"`
+		Language   namedResource `json:"language"`
+		Version    namedResource `json:"version"`
+	} `json:"flavor_text_entries"`
+	Habitat       *namedResource  `json:"habitat"`
+	Color         namedResource   `json:"color"`
+	Shape         *namedResource  `json:"shape"`
+	EggGroups     []namedResource `json:"egg_groups"`
+	BaseHappiness *int            `json:"base_happiness"`
+	IsBaby        bool            `json:"is_baby"`
+	IsLegendary   bool            `json:"is_legendary"`
+	IsMythical    bool            `json:"is_mythical"`
+}
+
+func fetchSpecies(url string, cache *pokecache.Cache) (*speciesResponse, error) {
+	return fetchResource[speciesResponse](url, cache)
+}
+
+type evolutionChainResponse struct {
+	ID    int       `json:"id"`
+	Chain chainLink `json:"chain"`
+}
+
+// chainLink is one species in an evolution chain, with the conditions to
+// evolve into it and the species it evolves into in turn.
+type chainLink struct {
+	IsBaby           bool              `json:"is_baby"`
+	Species          namedResource     `json:"species"`
+	EvolutionDetails []evolutionDetail `json:"evolution_details"`
+	EvolvesTo        []chainLink       `json:"evolves_to"`
+}
+
+type evolutionDetail struct {
+	Trigger            namedResource  `json:"trigger"`
+	MinLevel           *int           `json:"min_level"`
+	Item               *namedResource `json:"item"`
+	HeldItem           *namedResource `json:"held_item"`
+	Gender             *int           `json:"gender"`
+	KnownMove          *namedResource `json:"known_move"`
+	KnownMoveType      *namedResource `json:"known_move_type"`
+	Location           *namedResource `json:"location"`
+	MinHappiness       *int           `json:"min_happiness"`
+	MinAffection       *int           `json:"min_affection"`
+	MinBeauty          *int           `json:"min_beauty"`
+	TimeOfDay          string         `json:"time_of_day"`
+	TradeSpecies       *namedResource `json:"trade_species"`
+	PartySpecies       *namedResource `json:"party_species"`
+	PartyType          *namedResource `json:"party_type"`
+	NeedsOverworldRain bool           `json:"needs_overworld_rain"`
+	TurnUpsideDown     bool           `json:"turn_upside_down"`
+}
+
+func fetchEvolutionChain(url string, cache *pokecache.Cache) (*evolutionChainResponse, error) {
+	return fetchResource[evolutionChainResponse](url, cache)
+}
+
+type growthRateResponse struct {
+	ID      int    `json:"id"`
+	Name    string `json:"name"`
+	Formula string `json:"formula"`
+	Levels  []struct {
+		Level      int `json:"level"`
+		Experience int `json:"experience"`
+	} `json:"levels"`
+}
+
+func fetchGrowthRate(url string, cache *pokecache.Cache) (*growthRateResponse, error) {
+	return fetchResource[growthRateResponse](url, cache)
+}
+
+type natureResponse struct {
+	ID            int            `json:"id"`
+	Name          string         `json:"name"`
+	IncreasedStat *namedResource `json:"increased_stat"`
+	DecreasedStat *namedResource `json:"decreased_stat"`
+}
+
+func fetchNature(url string, cache *pokecache.Cache) (*natureResponse, error) {
+	return fetchResource[natureResponse](url, cache)
+}
+
+type abilityResponse struct {
+	ID            int           `json:"id"`
+	Name          string        `json:"name"`
+	Generation    namedResource `json:"generation"`
+	EffectEntries []struct {
+		Effect      string        `json:"effect"`
+		ShortEffect string        `json:"short_effect"`
+		Language    namedResource `json:"language"`
+	} `json:"effect_entries"`
+	Pokemon []struct {
+		IsHidden bool          `json:"is_hidden"`
+		Slot     int           `json:"slot"`
+		Pokemon  namedResource `json:"pokemon"`
+	} `json:"pokemon"`
+}
+
+func fetchAbility(url string, cache *pokecache.Cache) (*abilityResponse, error) {
+	return fetchResource[abilityResponse](url, cache)
+}
+
+type regionResponse struct {
+	ID             int             `json:"id"`
+	Name           string          `json:"name"`
+	MainGeneration *namedResource  `json:"main_generation"`
+	Locations      []namedResource `json:"locations"`
+	VersionGroups  []namedResource `json:"version_groups"`
+}
+
+func fetchRegion(url string, cache *pokecache.Cache) (*regionResponse, error) {
+	return fetchResource[regionResponse](url, cache)
+}
+
+type locationResponse struct {
+	ID     int             `json:"id"`
+	Name   string          `json:"name"`
+	Region *namedResource  `json:"region"`
+	Areas  []namedResource `json:"areas"`
+}
+
+func fetchLocation(url string, cache *pokecache.Cache) (*locationResponse, error) {
+	return fetchResource[locationResponse](url, cache)
+}
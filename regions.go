@@ -0,0 +1,128 @@
+package main
+
+import (
+	"fmt"
+)
+
+// mapPageSize is the number of location areas map shows at once, the
+// same as a page of PokeAPI's location-area list.
+const mapPageSize = 20
+
+// regionMap is the state of map when it is restricted to one region. The
+// region's locations are looked up only as far as the pages shown need
+// them, since each one is a separate request.
+type regionMap struct {
+	region    string
+	locations []namedResource
+	// loaded counts the locations whose areas are in areas.
+	loaded int
+	areas  []string
+	// start and end delimit the page shown last.
+	start, end int
+}
+
+// loadAreas looks up locations until there are at least n areas or every
+// location has been looked up.
+func (m *regionMap) loadAreas(cfg *config, n int) error {
+	for len(m.areas) < n && m.loaded < len(m.locations) {
+		location, err := fetchLocation(m.locations[m.loaded].URL, cfg.Cache)
+		if err != nil {
+			return err
+		}
+		for _, area := range location.Areas {
+			m.areas = append(m.areas, area.Name)
+		}
+		m.loaded++
+	}
+	return nil
+}
+
+func newRegionMap(cfg *config, region string) (*regionMap, error) {
+	res, err := fetchRegion(pokeAPIBaseURL+"region/"+region, cfg.Cache)
+	if err != nil {
+		return nil, fmt.Errorf("could not look up region %s: %w", region, err)
+	}
+	return &regionMap{region: res.Name, locations: res.Locations}, nil
+}
+
+// commandRegionMap shows the next page of the region's location areas.
+func commandRegionMap(cfg *config) error {
+	m := cfg.RegionMap
+	if err := m.loadAreas(cfg, m.end+mapPageSize); err != nil {
+		return err
+	}
+	if m.end >= len(m.areas) && m.end > 0 {
+		fmt.Printf("You're on the last page of %s\n", m.region)
+		return nil
+	}
+	m.start = m.end
+	m.end = min(m.start+mapPageSize, len(m.areas))
+	if m.start == m.end {
+		fmt.Printf("%s has no location areas\n", m.region)
+		return nil
+	}
+	cfg.emitLocalized("", "location-area", m.areas[m.start:m.end])
+	return nil
+}
+
+// commandRegionMapb shows the previous page of the region's location
+// areas. Those have been looked up already.
+func commandRegionMapb(cfg *config) error {
+	m := cfg.RegionMap
+	if m.start == 0 {
+		fmt.Println("You're on the first page")
+		return nil
+	}
+	m.start = max(m.start-mapPageSize, 0)
+	m.end = min(m.start+mapPageSize, len(m.areas))
+	cfg.emitLocalized("", "location-area", m.areas[m.start:m.end])
+	return nil
+}
+
+func commandRegions(cfg *config, args []string) error {
+	_ = args
+	res, err := fetchResourceList(pokeAPIBaseURL+"region?limit=100", cfg.Cache)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(res.Results))
+	for i, region := range res.Results {
+		names[i] = region.Name
+	}
+	cfg.emitLocalized("Regions:", "region", names)
+	return nil
+}
+
+func commandLocations(cfg *config, args []string) error {
+	region, err := fetchRegion(pokeAPIBaseURL+"region/"+resourceName(args[0]), cfg.Cache)
+	if err != nil {
+		return err
+	}
+	names := make([]string, len(region.Locations))
+	for i, location := range region.Locations {
+		names[i] = location.Name
+	}
+	cfg.emitLocalized(fmt.Sprintf("Locations in %s:", cfg.localize("region", region.Name)), "location", names)
+	return nil
+}
+
+func commandAreas(cfg *config, args []string) error {
+	location, err := fetchLocation(pokeAPIBaseURL+"location/"+resourceName(args[0]), cfg.Cache)
+	if err != nil {
+		return err
+	}
+	if len(location.Areas) == 0 && cfg.PipeOutput == nil {
+		fmt.Printf("%s has no location areas to explore\n", cfg.localize("location", location.Name))
+		return nil
+	}
+	names := make([]string, len(location.Areas))
+	for i, area := range location.Areas {
+		names[i] = area.Name
+	}
+	title := fmt.Sprintf("Areas of %s:", cfg.localize("location", location.Name))
+	if location.Region != nil {
+		title = fmt.Sprintf("Areas of %s (%s):", cfg.localize("location", location.Name), cfg.localize("region", location.Region.Name))
+	}
+	cfg.emitLocalized(title, "location-area", names)
+	return nil
+}
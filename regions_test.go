@@ -0,0 +1,107 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+// regionTestConfig has a region with a location of 15 areas, one of 10
+// areas and one without areas.
+func regionTestConfig(t *testing.T) *config {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"region/sinnoh", []byte(`{
+		"name": "sinnoh",
+		"locations": [
+			{"name": "mt-coronet", "url": "https://pokeapi.co/api/v2/location/1/"},
+			{"name": "eterna-forest", "url": "https://pokeapi.co/api/v2/location/2/"},
+			{"name": "sinnoh-pokemon-league", "url": "https://pokeapi.co/api/v2/location/3/"}
+		]
+	}`))
+	locations := map[int]int{1: 15, 2: 10, 3: 0}
+	for id, count := range locations {
+		areas := make([]string, count)
+		for i := range areas {
+			areas[i] = fmt.Sprintf(`{"name": "area-%d-%d"}`, id, i+1)
+		}
+		body := fmt.Sprintf(`{"name": "location-%d", "region": {"name": "sinnoh"}, "areas": [%s]}`, id, strings.Join(areas, ","))
+		cfg.Cache.Add(fmt.Sprintf("%slocation/%d/", pokeAPIBaseURL, id), []byte(body))
+	}
+	return cfg
+}
+
+func TestMapRegion(t *testing.T) {
+	cfg := regionTestConfig(t)
+	var output []string
+	cfg.PipeOutput = &output
+
+	page := func(callback func(*config, []string) error, flags map[string]string) []string {
+		output = output[:0]
+		cfg.Flags = flags
+		defer func() { cfg.Flags = nil }()
+		if err := callback(cfg, nil); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		return append([]string(nil), output...)
+	}
+
+	first := page(commandMap, map[string]string{"region": "Sinnoh"})
+	if len(first) != mapPageSize || first[0] != "area-1-1" || first[19] != "area-2-5" {
+		t.Errorf("Expected the first 20 areas, got %v", first)
+	}
+	if cfg.RegionMap.loaded != 2 {
+		t.Errorf("Expected only the locations needed to be looked up, got %d", cfg.RegionMap.loaded)
+	}
+
+	second := page(commandMap, nil)
+	if len(second) != 5 || second[0] != "area-2-6" {
+		t.Errorf("Expected the last 5 areas, got %v", second)
+	}
+	if last := page(commandMap, nil); len(last) != 0 {
+		t.Errorf("Expected nothing after the last page, got %v", last)
+	}
+
+	back := page(commandMapb, nil)
+	if len(back) != mapPageSize || back[0] != "area-1-1" {
+		t.Errorf("Expected the first page again, got %v", back)
+	}
+	if before := page(commandMapb, nil); len(before) != 0 {
+		t.Errorf("Expected nothing before the first page, got %v", before)
+	}
+
+	cfg.Cache.Add(pokeAPIBaseURL+"location-area/", []byte(`{"results": [{"name": "canalave-city-area"}]}`))
+	all := page(commandMap, map[string]string{"region": "all"})
+	if cfg.RegionMap != nil || len(all) != 1 || all[0] != "canalave-city-area" {
+		t.Errorf("Expected --region all to page through every area again, got %v", all)
+	}
+}
+
+func TestCommandAreasEmits(t *testing.T) {
+	cfg := regionTestConfig(t)
+	cfg.Cache.Add(pokeAPIBaseURL+"location/eterna-forest", []byte(`{
+		"name": "eterna-forest",
+		"region": {"name": "sinnoh"},
+		"areas": [{"name": "eterna-forest-area"}]
+	}`))
+
+	var output []string
+	cfg.PipeOutput = &output
+	if err := commandAreas(cfg, []string{"Eterna Forest"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(output) != 1 || output[0] != "eterna-forest-area" {
+		t.Errorf("Expected [eterna-forest-area], got %v", output)
+	}
+
+	output = output[:0]
+	if err := commandLocations(cfg, []string{"sinnoh"}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(output) != 3 || output[1] != "eterna-forest" {
+		t.Errorf("Expected the 3 locations of sinnoh, got %v", output)
+	}
+}
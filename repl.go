@@ -0,0 +1,192 @@
+package main
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+)
+
+// maxExpansionDepth bounds how deeply aliases and macros may refer to each
+// other, so that "alias a b" followed by "alias b a" cannot loop forever.
+const maxExpansionDepth = 16
+
+// runLine executes one line of input. The line may be a pipeline of
+// commands separated by '|', in which case the resources each command
+// emits are handed to the next one.
+func runLine(cfg *config, line string) error {
+	// A macro definition keeps its pipes, which only take effect when the
+	// macro runs.
+	if words, err := cleanInput(line); err == nil && len(words) > 0 && words[0] == "macro" {
+		return runCommand(cfg, words)
+	}
+	stages, err := parsePipeline(line)
+	if err != nil {
+		return err
+	}
+	return runPipeline(cfg, stages, 0)
+}
+
+// runCommand executes one tokenized command line, expanding aliases and
+// macros before looking the command up in the command table.
+func runCommand(cfg *config, words []string) error {
+	return dispatch(cfg, words, 0)
+}
+
+func parsePipeline(line string) ([][]string, error) {
+	var stages [][]string
+	for _, part := range splitUnquoted(line, '|') {
+		words, err := cleanInput(part)
+		if err != nil {
+			return nil, err
+		}
+		stages = append(stages, words)
+	}
+	if len(stages) > 1 {
+		for _, words := range stages {
+			if len(words) == 0 {
+				return nil, fmt.Errorf("empty command in pipeline")
+			}
+		}
+	}
+	return stages, nil
+}
+
+// runPipeline runs each stage with the previous stage's output as its
+// input. The first stage sees the caller's input and the last stage writes
+// to the caller's output, so a pipeline nested in a macro behaves like a
+// single command.
+func runPipeline(cfg *config, stages [][]string, depth int) error {
+	if len(stages) == 1 {
+		return dispatch(cfg, stages[0], depth)
+	}
+
+	outerInput, outerOutput := cfg.PipeInput, cfg.PipeOutput
+	defer func() {
+		cfg.PipeInput, cfg.PipeOutput = outerInput, outerOutput
+	}()
+
+	for i, stage := range stages {
+		output := []string{}
+		cfg.PipeOutput = &output
+		if i == len(stages)-1 {
+			cfg.PipeOutput = outerOutput
+		}
+		if err := dispatch(cfg, stage, depth); err != nil {
+			return err
+		}
+		cfg.PipeInput = output
+	}
+	return nil
+}
+
+func dispatch(cfg *config, words []string, depth int) error {
+	if len(words) == 0 {
+		return nil
+	}
+	if depth > maxExpansionDepth {
+		return fmt.Errorf("alias or macro expansion is nested too deeply")
+	}
+	name := words[0]
+
+	if target, ok := cfg.Settings.Aliases[name]; ok {
+		expanded, err := cleanInput(target)
+		if err != nil {
+			return fmt.Errorf("alias %s: %w", name, err)
+		}
+		return dispatch(cfg, append(expanded, words[1:]...), depth+1)
+	}
+
+	if body, ok := cfg.Settings.Macros[name]; ok {
+		for _, statement := range splitUnquoted(body, ';') {
+			stages, err := parsePipeline(statement)
+			if err != nil {
+				return fmt.Errorf("macro %s: %w", name, err)
+			}
+			for i := range stages {
+				stages[i] = expandMacroArgs(stages[i], words[1:])
+			}
+			if err := runPipeline(cfg, stages, depth+1); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	command, ok := commands[name]
+	if !ok {
+		return fmt.Errorf("unknown command: %s", name)
+	}
+
+	var flags map[string]string
+	args := words[1:]
+	if !command.passthrough {
+		var err error
+		flags, args, err = parseFlags(command.flags, words[1:])
+		if err != nil {
+			return fmt.Errorf("%w\nusage: %s", err, command.usageLine())
+		}
+	}
+	outerFlags := cfg.Flags
+	cfg.Flags = flags
+	defer func() { cfg.Flags = outerFlags }()
+
+	// Commands that don't read their input themselves are run once per
+	// piped resource, with the resource as their last argument.
+	if cfg.PipeInput != nil && !command.consumesInput {
+		input := cfg.PipeInput
+		cfg.PipeInput = nil
+		defer func() { cfg.PipeInput = input }()
+		for _, item := range input {
+			itemArgs := append(args[:len(args):len(args)], item)
+			if err := validateArgs(command, itemArgs); err != nil {
+				return err
+			}
+			if err := command.callback(cfg, itemArgs); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if err := validateArgs(command, args); err != nil {
+		return err
+	}
+	return command.callback(cfg, args)
+}
+
+// flag returns the value of the running command's --name option.
+func (cfg *config) flag(name string) (string, bool) {
+	value, ok := cfg.Flags[name]
+	return value, ok
+}
+
+var macroArgPattern = regexp.MustCompile(`\$(\d+)`)
+
+// expandMacroArgs substitutes $1..$N with the macro's arguments and a lone
+// $@ with all of them. A word that is only a placeholder for a missing
+// argument is dropped, so the command reports its own usage error.
+func expandMacroArgs(words []string, args []string) []string {
+	var expanded []string
+	for _, word := range words {
+		if word == "$@" {
+			expanded = append(expanded, args...)
+			continue
+		}
+		if macroArgPattern.MatchString(word) {
+			missing := false
+			word = macroArgPattern.ReplaceAllStringFunc(word, func(placeholder string) string {
+				n, _ := strconv.Atoi(placeholder[1:])
+				if n < 1 || n > len(args) {
+					missing = true
+					return ""
+				}
+				return args[n-1]
+			})
+			if missing && word == "" {
+				continue
+			}
+		}
+		expanded = append(expanded, word)
+	}
+	return expanded
+}
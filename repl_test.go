@@ -0,0 +1,177 @@
+package main
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+func newTestConfig(t *testing.T) *config {
+	t.Helper()
+	userSettings, err := loadSettings("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return &config{
+		Pokedex:   make(map[string]Pokemon),
+		Inventory: newGameInventory(),
+		Settings:  userSettings,
+		Rand:      rand.New(rand.NewSource(1)),
+	}
+}
+
+// recordCommand registers a command that records its arguments, options
+// included, for the duration of the test.
+func recordCommand(t *testing.T, name string) *[][]string {
+	t.Helper()
+	var calls [][]string
+	commands[name] = cliCommand{
+		name:        name,
+		args:        []argSpec{{name: "args", optional: true, variadic: true}},
+		passthrough: true,
+		callback: func(cfg *config, args []string) error {
+			calls = append(calls, args)
+			return nil
+		},
+	}
+	t.Cleanup(func() { delete(commands, name) })
+	return &calls
+}
+
+func TestDispatchAlias(t *testing.T) {
+	cfg := newTestConfig(t)
+	calls := recordCommand(t, "record")
+	cfg.Settings.Aliases["r"] = "record first"
+
+	if err := runCommand(cfg, []string{"r", "second"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{{"first", "second"}}
+	if !reflect.DeepEqual(*calls, expected) {
+		t.Errorf("Expected calls %q, got %q", expected, *calls)
+	}
+}
+
+func TestDispatchMacro(t *testing.T) {
+	cfg := newTestConfig(t)
+	calls := recordCommand(t, "record")
+	cfg.Settings.Aliases["r"] = "record"
+	cfg.Settings.Macros["hunt"] = `record area $1; r $2 'Two Words'; record $@`
+
+	if err := runCommand(cfg, []string{"hunt", "canalave", "Pikachu"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{
+		{"area", "canalave"},
+		{"Pikachu", "Two Words"},
+		{"canalave", "Pikachu"},
+	}
+	if !reflect.DeepEqual(*calls, expected) {
+		t.Errorf("Expected calls %q, got %q", expected, *calls)
+	}
+}
+
+func TestDispatchRecursiveAlias(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Settings.Aliases["a"] = "b"
+	cfg.Settings.Aliases["b"] = "a"
+
+	if err := runCommand(cfg, []string{"a"}); err == nil {
+		t.Error("Expected an error for a recursive alias, got none")
+	}
+}
+
+func TestExpandMacroArgs(t *testing.T) {
+	tests := []struct {
+		words    []string
+		args     []string
+		expected []string
+	}{
+		{words: []string{"catch", "$1"}, args: []string{"pikachu"}, expected: []string{"catch", "pikachu"}},
+		{words: []string{"catch", "$2"}, args: []string{"pikachu"}, expected: []string{"catch"}},
+		{words: []string{"explore", "$1-area"}, args: []string{"canalave"}, expected: []string{"explore", "canalave-area"}},
+		{words: []string{"say", "$@"}, args: []string{"a", "b"}, expected: []string{"say", "a", "b"}},
+	}
+
+	for _, test := range tests {
+		actual := expandMacroArgs(test.words, test.args)
+		if !reflect.DeepEqual(actual, test.expected) {
+			t.Errorf("Words: %q Args: %q - Expected %q, got %q", test.words, test.args, test.expected, actual)
+		}
+	}
+}
+
+// ballCommand registers a command with a --ball option that records the
+// ball it was given.
+func ballCommand(t *testing.T, name string) *[]string {
+	t.Helper()
+	var balls []string
+	commands[name] = cliCommand{
+		name:  name,
+		args:  []argSpec{{name: "pokemon"}},
+		flags: []flagSpec{{name: "ball", value: "ball"}},
+		callback: func(cfg *config, args []string) error {
+			ball, _ := cfg.flag("ball")
+			balls = append(balls, ball)
+			return nil
+		},
+	}
+	t.Cleanup(func() { delete(commands, name) })
+	return &balls
+}
+
+func TestAliasKeepsOptions(t *testing.T) {
+	cfg := newTestConfig(t)
+	balls := ballCommand(t, "throw")
+
+	if err := runLine(cfg, "alias cu throw --ball ultra"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runLine(cfg, "cu pikachu"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*balls, []string{"ultra"}) {
+		t.Errorf("Expected the alias to throw an ultra ball, got %q", *balls)
+	}
+}
+
+func TestMacroKeepsOptions(t *testing.T) {
+	cfg := newTestConfig(t)
+	calls := recordCommand(t, "record")
+	balls := ballCommand(t, "throw")
+
+	if err := runLine(cfg, "macro hunt = record $1; throw $2 --ball great"); err != nil {
+		t.Fatal(err)
+	}
+	if err := runLine(cfg, "hunt route-1 pidgey"); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(*calls, [][]string{{"route-1"}}) || !reflect.DeepEqual(*balls, []string{"great"}) {
+		t.Errorf("Expected the macro to record route-1 and throw a great ball, got %q and %q", *calls, *balls)
+	}
+}
+
+func TestMacroKeepsPipes(t *testing.T) {
+	cfg := newTestConfig(t)
+	emitCommand(t, "source", "geodude", "onix")
+	calls := recordCommand(t, "record")
+
+	if err := runLine(cfg, "macro rocks = source | record; record done"); err != nil {
+		t.Fatal(err)
+	}
+	if len(*calls) != 0 {
+		t.Fatalf("Expected defining the macro to run nothing, got %q", *calls)
+	}
+	if body := cfg.Settings.Macros["rocks"]; body != "source | record; record done" {
+		t.Errorf("Expected the whole body to be stored, got %q", body)
+	}
+	if err := runLine(cfg, "rocks"); err != nil {
+		t.Fatal(err)
+	}
+	expected := [][]string{{"geodude"}, {"onix"}, {"done"}}
+	if !reflect.DeepEqual(*calls, expected) {
+		t.Errorf("Expected %q, got %q", expected, *calls)
+	}
+}
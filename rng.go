@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// seedRand makes cfg.Rand a fresh generator seeded with seed. Everything
+// random in the game draws from cfg.Rand, so a session started with the
+// same seed and fed the same commands plays out the same way.
+func seedRand(cfg *config, seed int64) {
+	cfg.Seed = seed
+	cfg.Rand = rand.New(rand.NewSource(seed))
+	cfg.logf("seed %d", seed)
+}
+
+func randomSeed() int64 {
+	return time.Now().UnixNano()
+}
+
+func commandSeed(cfg *config, args []string) error {
+	if len(args) == 0 {
+		fmt.Printf("Seed: %d\n", cfg.Seed)
+		return nil
+	}
+
+	seed := randomSeed()
+	if strings.ToLower(args[0]) != "random" {
+		var err error
+		seed, err = strconv.ParseInt(args[0], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid seed: %s", args[0])
+		}
+	}
+	seedRand(cfg, seed)
+	fmt.Printf("Seed set to %d\n", seed)
+	return nil
+}
+
+func defaultSessionLogPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pokedexcli", "session.log")
+}
+
+// openSessionLog opens the session log for appending. The log records the
+// seed and every command entered, which is what it takes to reproduce a
+// session in a bug report.
+func openSessionLog(path string) (*os.File, error) {
+	if path == "" {
+		return nil, nil
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, err
+	}
+	return os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o644)
+}
+
+// logf appends a timestamped line to the session log, if there is one.
+func (cfg *config) logf(format string, args ...any) {
+	if cfg.SessionLog == nil {
+		return
+	}
+	line := fmt.Sprintf(format, args...)
+	fmt.Fprintf(cfg.SessionLog, "%s %s\n", time.Now().Format(time.RFC3339), line)
+}
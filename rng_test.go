@@ -0,0 +1,48 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestSeedRandIsReproducible(t *testing.T) {
+	roll := func(seed int64) []int {
+		cfg := newTestConfig(t)
+		seedRand(cfg, seed)
+		rolls := make([]int, 5)
+		for i := range rolls {
+			rolls[i] = cfg.Rand.Intn(100)
+		}
+		return rolls
+	}
+
+	first, second := roll(1234), roll(1234)
+	for i := range first {
+		if first[i] != second[i] {
+			t.Fatalf("Expected the same seed to give the same rolls, got %v and %v", first, second)
+		}
+	}
+}
+
+func TestCommandSeed(t *testing.T) {
+	cfg := newTestConfig(t)
+	var log bytes.Buffer
+	cfg.SessionLog = &log
+
+	if err := commandSeed(cfg, []string{"42"}); err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Seed != 42 {
+		t.Errorf("Expected seed 42, got %d", cfg.Seed)
+	}
+	if !strings.Contains(log.String(), "seed 42") {
+		t.Errorf("Expected the seed to be logged, got %q", log.String())
+	}
+	if err := commandSeed(cfg, []string{"not-a-number"}); err == nil {
+		t.Error("Expected an invalid seed to fail, got no error")
+	}
+	if err := commandSeed(cfg, []string{"random"}); err != nil {
+		t.Fatal(err)
+	}
+}
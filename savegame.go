@@ -0,0 +1,86 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// saveData is the part of the game state that is written to disk: the
+// Pokedex, every caught Pokemon, the active party and the bag.
+type saveData struct {
+	Pokedex     map[string]Pokemon `json:"pokedex"`
+	Seen        map[string]bool    `json:"seen"`
+	Box         []*OwnedPokemon    `json:"box"`
+	NextOwnedID int                `json:"next_owned_id"`
+	Party       []int              `json:"party"`
+	Inventory   map[string]int     `json:"inventory"`
+	Location    string             `json:"location,omitempty"`
+}
+
+func defaultSavePath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pokedexcli", "save.json")
+}
+
+// loadGame restores the saved game from cfg.SavePath into cfg. A missing
+// save file leaves cfg untouched.
+func loadGame(cfg *config) error {
+	if cfg.SavePath == "" {
+		return nil
+	}
+	data, err := os.ReadFile(cfg.SavePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var save saveData
+	if err := json.Unmarshal(data, &save); err != nil {
+		return err
+	}
+	if save.Pokedex != nil {
+		cfg.Pokedex = save.Pokedex
+	}
+	cfg.Seen = save.Seen
+	cfg.Box = save.Box
+	cfg.NextOwnedID = save.NextOwnedID
+	cfg.Party = save.Party
+	cfg.Location = save.Location
+	// Saves from before the bag existed keep the new game inventory.
+	if save.Inventory != nil {
+		cfg.Inventory = save.Inventory
+	}
+	return nil
+}
+
+// saveGame writes the game state to cfg.SavePath. Without a save path the
+// game is kept in memory only.
+func saveGame(cfg *config) error {
+	if cfg.SavePath == "" {
+		return nil
+	}
+	save := saveData{
+		Pokedex:     cfg.Pokedex,
+		Seen:        cfg.Seen,
+		Box:         cfg.Box,
+		NextOwnedID: cfg.NextOwnedID,
+		Party:       cfg.Party,
+		Inventory:   cfg.Inventory,
+		Location:    cfg.Location,
+	}
+	data, err := json.MarshalIndent(save, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(cfg.SavePath), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(cfg.SavePath, data, 0o644)
+}
@@ -0,0 +1,226 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"go.starlark.net/starlark"
+	"go.starlark.net/syntax"
+)
+
+// maxScriptSteps stops runaway scripts such as an accidental infinite loop.
+const maxScriptSteps = 50_000_000
+
+// Scripts are written in Starlark, a small Python dialect. The interpreter
+// has no builtins for files, the network or the clock; the only way out of
+// the sandbox is through the functions defined below, which go through the
+// command table and the cached PokeAPI client.
+var scriptFileOptions = &syntax.FileOptions{
+	Set:             true,
+	While:           true,
+	TopLevelControl: true,
+	GlobalReassign:  true,
+}
+
+func commandRun(cfg *config, args []string) error {
+	if cfg.RunningScript {
+		return fmt.Errorf("scripts cannot run other scripts")
+	}
+	path := args[0]
+	src, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+
+	cfg.RunningScript = true
+	defer func() { cfg.RunningScript = false }()
+
+	return runScript(cfg, path, src, args[1:])
+}
+
+func runScript(cfg *config, filename string, src []byte, args []string) error {
+	thread := &starlark.Thread{
+		Name: filename,
+		Print: func(_ *starlark.Thread, msg string) {
+			fmt.Println(msg)
+		},
+		Load: func(_ *starlark.Thread, module string) (starlark.StringDict, error) {
+			return nil, fmt.Errorf("cannot load %s: load is disabled in scripts", module)
+		},
+	}
+	thread.SetMaxExecutionSteps(maxScriptSteps)
+
+	argv := make([]starlark.Value, len(args))
+	for i, arg := range args {
+		argv[i] = starlark.String(arg)
+	}
+
+	predeclared := starlark.StringDict{
+		"argv":     starlark.NewList(argv),
+		"command":  starlark.NewBuiltin("command", scriptCommand(cfg)),
+		"commands": starlark.NewBuiltin("commands", scriptCommands),
+		"pokeapi":  starlark.NewBuiltin("pokeapi", scriptPokeAPI(cfg)),
+		"pokedex":  starlark.NewBuiltin("pokedex", scriptPokedex(cfg)),
+	}
+
+	_, err := starlark.ExecFileOptions(scriptFileOptions, thread, filename, src, predeclared)
+	if evalErr, ok := err.(*starlark.EvalError); ok {
+		return fmt.Errorf("%s", evalErr.Backtrace())
+	}
+	return err
+}
+
+type scriptBuiltin = func(*starlark.Thread, *starlark.Builtin, starlark.Tuple, []starlark.Tuple) (starlark.Value, error)
+
+// scriptCommand implements command(name, *args), which runs a REPL
+// command exactly as if it had been typed in.
+func scriptCommand(cfg *config) scriptBuiltin {
+	return func(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if len(kwargs) > 0 {
+			return nil, fmt.Errorf("%s: unexpected keyword arguments", fn.Name())
+		}
+		if len(args) == 0 {
+			return nil, fmt.Errorf("%s: missing command name", fn.Name())
+		}
+
+		words := make([]string, len(args))
+		for i, arg := range args {
+			if s, ok := starlark.AsString(arg); ok {
+				words[i] = s
+			} else {
+				words[i] = arg.String()
+			}
+		}
+		words[0] = strings.ToLower(words[0])
+
+		if err := runCommand(cfg, words); err != nil {
+			return nil, fmt.Errorf("%s: %w", fn.Name(), err)
+		}
+		return starlark.None, nil
+	}
+}
+
+// scriptCommands implements commands(), the sorted list of command names.
+func scriptCommands(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+	if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 0); err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return stringList(names), nil
+}
+
+// scriptPokeAPI implements pokeapi(resource), which fetches a PokeAPI
+// resource such as "location-area/canalave-city-area" and returns the
+// decoded JSON. Full URLs are accepted as long as they point at the
+// PokeAPI, so "url" fields of earlier responses can be followed.
+func scriptPokeAPI(cfg *config) scriptBuiltin {
+	return func(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		var resource string
+		if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 1, &resource); err != nil {
+			return nil, err
+		}
+
+		url := resource
+		if !strings.HasPrefix(url, pokeAPIBaseURL) {
+			if strings.Contains(url, "://") {
+				return nil, fmt.Errorf("%s: scripts may only access %s", fn.Name(), pokeAPIBaseURL)
+			}
+			url = pokeAPIBaseURL + strings.TrimPrefix(url, "/")
+		}
+
+		body, err := fetchData(url, cfg.Cache)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn.Name(), err)
+		}
+		value, err := decodeStarlarkJSON(body)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fn.Name(), err)
+		}
+		return value, nil
+	}
+}
+
+// scriptPokedex implements pokedex(), the sorted names of caught Pokemon.
+func scriptPokedex(cfg *config) scriptBuiltin {
+	return func(_ *starlark.Thread, fn *starlark.Builtin, args starlark.Tuple, kwargs []starlark.Tuple) (starlark.Value, error) {
+		if err := starlark.UnpackPositionalArgs(fn.Name(), args, kwargs, 0); err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(cfg.Pokedex))
+		for name := range cfg.Pokedex {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return stringList(names), nil
+	}
+}
+
+func stringList(values []string) *starlark.List {
+	list := make([]starlark.Value, len(values))
+	for i, v := range values {
+		list[i] = starlark.String(v)
+	}
+	return starlark.NewList(list)
+}
+
+func decodeStarlarkJSON(data []byte) (starlark.Value, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+	var v interface{}
+	if err := decoder.Decode(&v); err != nil {
+		return nil, err
+	}
+	return toStarlark(v)
+}
+
+func toStarlark(v interface{}) (starlark.Value, error) {
+	switch v := v.(type) {
+	case nil:
+		return starlark.None, nil
+	case bool:
+		return starlark.Bool(v), nil
+	case string:
+		return starlark.String(v), nil
+	case json.Number:
+		if i, err := v.Int64(); err == nil {
+			return starlark.MakeInt64(i), nil
+		}
+		f, err := v.Float64()
+		if err != nil {
+			return nil, err
+		}
+		return starlark.Float(f), nil
+	case []interface{}:
+		list := make([]starlark.Value, len(v))
+		for i, elem := range v {
+			value, err := toStarlark(elem)
+			if err != nil {
+				return nil, err
+			}
+			list[i] = value
+		}
+		return starlark.NewList(list), nil
+	case map[string]interface{}:
+		dict := starlark.NewDict(len(v))
+		for key, elem := range v {
+			value, err := toStarlark(elem)
+			if err != nil {
+				return nil, err
+			}
+			if err := dict.SetKey(starlark.String(key), value); err != nil {
+				return nil, err
+			}
+		}
+		return dict, nil
+	default:
+		return nil, fmt.Errorf("unsupported JSON value %T", v)
+	}
+}
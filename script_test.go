@@ -0,0 +1,93 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+func TestRunScriptCallsCommands(t *testing.T) {
+	cfg := newTestConfig(t)
+	calls := recordCommand(t, "record")
+
+	src := `
+for name in argv:
+    command("record", name, 5)
+`
+	if err := runScript(cfg, "test.star", []byte(src), []string{"a", "b"}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{{"a", "5"}, {"b", "5"}}
+	if !reflect.DeepEqual(*calls, expected) {
+		t.Errorf("Expected calls %q, got %q", expected, *calls)
+	}
+}
+
+func TestRunScriptPokeAPI(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"location-area/test-area",
+		[]byte(`{"pokemon_encounters": [{"pokemon": {"name": "pikachu"}}, {"pokemon": {"name": "geodude"}}], "id": 7}`))
+	calls := recordCommand(t, "record")
+
+	src := `
+area = pokeapi("location-area/test-area")
+for encounter in area["pokemon_encounters"]:
+    command("record", encounter["pokemon"]["name"], area["id"] + 1)
+`
+	if err := runScript(cfg, "test.star", []byte(src), nil); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{{"pikachu", "8"}, {"geodude", "8"}}
+	if !reflect.DeepEqual(*calls, expected) {
+		t.Errorf("Expected calls %q, got %q", expected, *calls)
+	}
+}
+
+func TestRunScriptSandbox(t *testing.T) {
+	cfg := newTestConfig(t)
+
+	tests := []struct {
+		src      string
+		contains string
+	}{
+		{src: `load("os.star", "open")`, contains: "load is disabled"},
+		{src: `pokeapi("https://example.com/secrets")`, contains: "may only access"},
+		{src: `open("/etc/passwd")`, contains: "undefined: open"},
+		{src: `command("run", "other.star")`, contains: "cannot run other scripts"},
+		{src: "while True:\n    pass\n", contains: "too many steps"},
+	}
+
+	cfg.RunningScript = true
+	for _, test := range tests {
+		err := runScript(cfg, "test.star", []byte(test.src), nil)
+		if err == nil || !strings.Contains(err.Error(), test.contains) {
+			t.Errorf("Script: %q - Expected error containing %q, got %v", test.src, test.contains, err)
+		}
+	}
+}
+
+func TestRunKeepsScriptOptions(t *testing.T) {
+	cfg := newTestConfig(t)
+	calls := recordCommand(t, "record")
+	path := filepath.Join(t.TempDir(), "x.star")
+	if err := os.WriteFile(path, []byte(`command("record", *argv)`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runLine(cfg, "run "+quoteWord(path)+" --verbose"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := [][]string{{"--verbose"}}
+	if !reflect.DeepEqual(*calls, expected) {
+		t.Errorf("Expected calls %q, got %q", expected, *calls)
+	}
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+)
+
+// settings holds user preferences that survive between sessions. They are
+// stored as JSON in the user's config directory.
+type settings struct {
+	Aliases map[string]string `json:"aliases"`
+	Macros  map[string]string `json:"macros"`
+	// GameMode is gameModeFree or gameModeEncounter.
+	GameMode string `json:"game_mode,omitempty"`
+	// Language is a PokeAPI language code such as "de" or "ja-Hrkt" to
+	// show names in, or empty for PokeAPI's identifiers.
+	Language string `json:"language,omitempty"`
+
+	path string
+}
+
+func defaultSettingsPath() string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "pokedexcli", "config.json")
+}
+
+// loadSettings reads the settings file at path. A missing file is not an
+// error and yields empty settings. An empty path keeps settings in memory
+// only.
+func loadSettings(path string) (*settings, error) {
+	s := &settings{
+		Aliases: make(map[string]string),
+		Macros:  make(map[string]string),
+		path:    path,
+	}
+	if path == "" {
+		return s, nil
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, s); err != nil {
+		return s, err
+	}
+
+	if s.Aliases == nil {
+		s.Aliases = make(map[string]string)
+	}
+	if s.Macros == nil {
+		s.Macros = make(map[string]string)
+	}
+	return s, nil
+}
+
+func (s *settings) save() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(s.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(s.path, data, 0o644)
+}
+
+func (s *settings) gameMode() string {
+	if s.GameMode == "" {
+		return gameModeFree
+	}
+	return s.GameMode
+}
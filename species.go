@@ -0,0 +1,126 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// lookupSpecies fetches a species by its own name, or by the name of one
+// of its Pokemon, such as deoxys-attack.
+func lookupSpecies(cfg *config, name string) (*speciesResponse, error) {
+	if pokemon, ok := cfg.Pokedex[name]; ok {
+		return fetchSpecies(pokemon.Species.URL, cfg.Cache)
+	}
+	species, err := fetchSpecies(pokeAPIBaseURL+"pokemon-species/"+name, cfg.Cache)
+	if err == nil {
+		return species, nil
+	}
+	pokemon, pokemonErr := lookupPokemon(cfg, name)
+	if pokemonErr != nil {
+		return nil, err
+	}
+	return fetchSpecies(pokemon.Species.URL, cfg.Cache)
+}
+
+// flavorText groups the Pokedex entries of a species by text,
+// listing the games that share each one. PokeAPI keeps the line breaks
+// and page breaks of the games, which are collapsed here.
+func flavorText(species *speciesResponse, game, lang string) []string {
+	// Show the entries in the chosen language if there are any, and the
+	// English ones otherwise.
+	entryLang := "en"
+	for _, entry := range species.FlavorTextEntries {
+		if lang != "" && strings.EqualFold(entry.Language.Name, lang) {
+			entryLang = entry.Language.Name
+		}
+	}
+
+	var texts []string
+	versions := make(map[string][]string)
+	for _, entry := range species.FlavorTextEntries {
+		if entry.Language.Name != entryLang || (game != "" && entry.Version.Name != game) {
+			continue
+		}
+		text := strings.Join(strings.Fields(entry.FlavorText), " ")
+		if _, ok := versions[text]; !ok {
+			texts = append(texts, text)
+		}
+		versions[text] = append(versions[text], entry.Version.Name)
+	}
+
+	lines := make([]string, len(texts))
+	for i, text := range texts {
+		lines[i] = fmt.Sprintf("%s: %s", strings.Join(versions[text], ", "), text)
+	}
+	return lines
+}
+
+func genus(species *speciesResponse, lang string) string {
+	texts := make(map[string]string)
+	for _, g := range species.Genera {
+		texts[g.Language.Name] = g.Genus
+	}
+	return pickLocalized(lang, "", texts)
+}
+
+// printSpecies shows the species data shared by every Pokemon of a
+// species, optionally with the Pokedex entries of one game only. Texts are
+// in lang if PokeAPI has them, and in English otherwise.
+func printSpecies(species *speciesResponse, game, lang string) {
+	if g := genus(species, lang); g != "" {
+		fmt.Printf("Genus: %s\n", g)
+	}
+	var status []string
+	if species.IsBaby {
+		status = append(status, "baby")
+	}
+	if species.IsLegendary {
+		status = append(status, "legendary")
+	}
+	if species.IsMythical {
+		status = append(status, "mythical")
+	}
+	if len(status) > 0 {
+		fmt.Printf("Status: %s\n", strings.Join(status, ", "))
+	}
+	fmt.Printf("Capture rate: %d\n", species.CaptureRate)
+	if species.BaseHappiness != nil {
+		fmt.Printf("Base happiness: %d\n", *species.BaseHappiness)
+	}
+	if species.Habitat != nil {
+		fmt.Printf("Habitat: %s\n", species.Habitat.Name)
+	}
+	fmt.Printf("Color: %s\n", species.Color.Name)
+	if species.Shape != nil {
+		fmt.Printf("Shape: %s\n", species.Shape.Name)
+	}
+	eggGroups := make([]string, len(species.EggGroups))
+	for i, group := range species.EggGroups {
+		eggGroups[i] = group.Name
+	}
+	fmt.Printf("Egg groups: %s\n", strings.Join(eggGroups, ", "))
+
+	entries := flavorText(species, game, lang)
+	if len(entries) == 0 {
+		return
+	}
+	fmt.Printf("Pokedex entries:\n")
+	for _, entry := range entries {
+		fmt.Printf("  - %s\n", entry)
+	}
+}
+
+func commandSpecies(cfg *config, args []string) error {
+	name := resourceName(args[0])
+	if slug := cfg.unlocalize("pokemon", args[0], nil); slug != "" {
+		name = slug
+	}
+	species, err := lookupSpecies(cfg, name)
+	if err != nil {
+		return err
+	}
+	game, _ := cfg.flag("game")
+	fmt.Printf("%s (#%d)\n", cfg.localize("pokemon", species.Name), species.ID)
+	printSpecies(species, resourceName(game), cfg.Settings.language())
+	return nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+const pikachuSpeciesFixture = `{
+	"id": 25,
+	"name": "pikachu",
+	"genera": [
+		{"genus": "Maus-Pokémon", "language": {"name": "de"}},
+		{"genus": "Mouse Pokémon", "language": {"name": "en"}}
+	],
+	"flavor_text_entries": [
+		{"flavor_text": "When several of\nthese POKéMON\fgather, their\nelectricity could\nbuild and cause\nlightning storms.", "language": {"name": "en"}, "version": {"name": "red"}},
+		{"flavor_text": "When several of\nthese POKéMON\fgather, their\nelectricity could\nbuild and cause\nlightning storms.", "language": {"name": "en"}, "version": {"name": "blue"}},
+		{"flavor_text": "It keeps its tail\nraised to monitor\nits surroundings.", "language": {"name": "en"}, "version": {"name": "yellow"}},
+		{"flavor_text": "Wenn mehrere...", "language": {"name": "de"}, "version": {"name": "red"}}
+	]
+}`
+
+func TestFlavorText(t *testing.T) {
+	var species speciesResponse
+	if err := json.Unmarshal([]byte(pikachuSpeciesFixture), &species); err != nil {
+		t.Fatalf("Invalid fixture: %v", err)
+	}
+
+	entries := flavorText(&species, "", "")
+	expected := []string{
+		"red, blue: When several of these POKéMON gather, their electricity could build and cause lightning storms.",
+		"yellow: It keeps its tail raised to monitor its surroundings.",
+	}
+	if len(entries) != len(expected) {
+		t.Fatalf("Expected %q, got %q", expected, entries)
+	}
+	for i := range expected {
+		if entries[i] != expected[i] {
+			t.Errorf("Expected %q, got %q", expected[i], entries[i])
+		}
+	}
+
+	if entries := flavorText(&species, "yellow", ""); len(entries) != 1 || entries[0] != expected[1] {
+		t.Errorf("Expected only the yellow entry, got %q", entries)
+	}
+	if g := genus(&species, ""); g != "Mouse Pokémon" {
+		t.Errorf("Expected the English genus, got %q", g)
+	}
+}
@@ -0,0 +1,197 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"os"
+	"strings"
+)
+
+const (
+	spriteTrueColor = "truecolor"
+	sprite256       = "256"
+	spriteASCII     = "ascii"
+)
+
+// asciiRamp goes from the lightest to the darkest character.
+const asciiRamp = " .:-=+*#%@"
+
+// spriteColorMode picks the richest output the terminal supports, going by
+// the usual environment variables.
+func spriteColorMode() string {
+	if _, ok := os.LookupEnv("NO_COLOR"); ok {
+		return spriteASCII
+	}
+	if colorterm := os.Getenv("COLORTERM"); colorterm == "truecolor" || colorterm == "24bit" {
+		return spriteTrueColor
+	}
+	if strings.Contains(os.Getenv("TERM"), "256color") {
+		return sprite256
+	}
+	return spriteASCII
+}
+
+// spriteURL picks the front sprite matching an owned Pokemon's gender and
+// shininess, falling back to the default sprite.
+func spriteURL(p Pokemon, owned *OwnedPokemon) string {
+	female := owned.Gender == "female"
+	candidates := []string{p.Sprites.FrontDefault}
+	if owned.Shiny {
+		candidates = []string{p.Sprites.FrontShiny, p.Sprites.FrontDefault}
+		if female {
+			candidates = append([]string{p.Sprites.FrontShinyFemale}, candidates...)
+		}
+	} else if female {
+		candidates = []string{p.Sprites.FrontFemale, p.Sprites.FrontDefault}
+	}
+	for _, url := range candidates {
+		if url != "" {
+			return url
+		}
+	}
+	return ""
+}
+
+// fetchSprite downloads and decodes a PNG sprite through the cache.
+func fetchSprite(cfg *config, url string) (image.Image, error) {
+	data, err := fetchData(url, cfg.Cache)
+	if err != nil {
+		return nil, fmt.Errorf("could not download sprite: %w", err)
+	}
+	img, err := png.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("could not decode sprite: %w", err)
+	}
+	return img, nil
+}
+
+// renderSprite draws an image as lines of terminal art, cropped to its
+// visible pixels. The color modes draw two pixels per character with the
+// upper half block; ASCII art uses one character per two pixel rows too,
+// since terminal cells are about twice as tall as they are wide.
+func renderSprite(img image.Image, mode string) []string {
+	bounds := visibleBounds(img)
+	var lines []string
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += 2 {
+		var line strings.Builder
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			top := img.At(x, y)
+			bottom := color.Color(color.Transparent)
+			if y+1 < bounds.Max.Y {
+				bottom = img.At(x, y+1)
+			}
+			if mode == spriteASCII {
+				line.WriteByte(asciiCell(top, bottom))
+			} else {
+				line.WriteString(colorCell(top, bottom, mode))
+			}
+		}
+		if mode != spriteASCII {
+			line.WriteString("\x1b[0m")
+		}
+		lines = append(lines, strings.TrimRight(line.String(), " "))
+	}
+	return lines
+}
+
+// visibleBounds is the smallest rectangle holding every opaque pixel.
+func visibleBounds(img image.Image) image.Rectangle {
+	b := img.Bounds()
+	visible := image.Rectangle{}
+	for y := b.Min.Y; y < b.Max.Y; y++ {
+		for x := b.Min.X; x < b.Max.X; x++ {
+			if opaque(img.At(x, y)) {
+				visible = visible.Union(image.Rect(x, y, x+1, y+1))
+			}
+		}
+	}
+	return visible
+}
+
+func opaque(c color.Color) bool {
+	_, _, _, a := c.RGBA()
+	return a >= 0x8000
+}
+
+func colorCell(top, bottom color.Color, mode string) string {
+	switch {
+	case opaque(top) && opaque(bottom):
+		return fmt.Sprintf("\x1b[%s;%sm▀", ansiColor(top, mode, false), ansiColor(bottom, mode, true))
+	case opaque(top):
+		return fmt.Sprintf("\x1b[0;%sm▀", ansiColor(top, mode, false))
+	case opaque(bottom):
+		return fmt.Sprintf("\x1b[0;%sm▄", ansiColor(bottom, mode, false))
+	}
+	return "\x1b[0m "
+}
+
+// ansiColor is the SGR parameter setting the foreground, or the background
+// if background is set, to c.
+func ansiColor(c color.Color, mode string, background bool) string {
+	r, g, b := rgb8(c)
+	base := 38
+	if background {
+		base = 48
+	}
+	if mode == sprite256 {
+		return fmt.Sprintf("%d;5;%d", base, xterm256(r, g, b))
+	}
+	return fmt.Sprintf("%d;2;%d;%d;%d", base, r, g, b)
+}
+
+func rgb8(c color.Color) (uint8, uint8, uint8) {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	return n.R, n.G, n.B
+}
+
+// xterm256 maps a color to the nearest entry of the 6x6x6 color cube in
+// the xterm 256-color palette.
+func xterm256(r, g, b uint8) int {
+	level := func(v uint8) int {
+		return (int(v)*5 + 127) / 255
+	}
+	return 16 + 36*level(r) + 6*level(g) + level(b)
+}
+
+// asciiCell shades two stacked pixels by their average brightness.
+func asciiCell(top, bottom color.Color) byte {
+	total, count := 0.0, 0
+	for _, c := range []color.Color{top, bottom} {
+		if opaque(c) {
+			r, g, b := rgb8(c)
+			total += 0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)
+			count++
+		}
+	}
+	if count == 0 {
+		return ' '
+	}
+	// Dark pixels get dense characters, and any visible pixel gets at
+	// least a dot so the outline doesn't disappear.
+	darkness := 1 - total/float64(count)/255
+	i := 1 + int(darkness*float64(len(asciiRamp)-2)+0.5)
+	return asciiRamp[i]
+}
+
+// printSprite shows an owned Pokemon's sprite, in the terminal's color
+// mode unless mode is given.
+func printSprite(cfg *config, owned *OwnedPokemon, mode string) error {
+	url := spriteURL(cfg.Pokedex[owned.Species], owned)
+	if url == "" {
+		return fmt.Errorf("%s has no sprite", owned.Species)
+	}
+	img, err := fetchSprite(cfg, url)
+	if err != nil {
+		return err
+	}
+	if mode == "" {
+		mode = spriteColorMode()
+	}
+	for _, line := range renderSprite(img, mode) {
+		fmt.Println(line)
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+// spriteFixture is a 4x4 PNG with a transparent border around two red
+// pixels above two blue ones.
+func spriteFixture(t *testing.T) []byte {
+	img := image.NewNRGBA(image.Rect(0, 0, 4, 4))
+	red := color.NRGBA{R: 255, A: 255}
+	blue := color.NRGBA{B: 255, A: 255}
+	img.Set(1, 1, red)
+	img.Set(2, 1, red)
+	img.Set(1, 2, blue)
+	img.Set(2, 2, blue)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		t.Fatalf("Could not encode fixture: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestRenderSprite(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	url := "https://raw.githubusercontent.com/PokeAPI/sprites/master/sprites/pokemon/25.png"
+	cfg.Cache.Add(url, spriteFixture(t))
+
+	img, err := fetchSprite(cfg, url)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	tests := []struct {
+		mode     string
+		expected string
+	}{
+		{mode: spriteTrueColor, expected: strings.Repeat("\x1b[38;2;255;0;0;48;2;0;0;255m▀", 2) + "\x1b[0m"},
+		{mode: sprite256, expected: strings.Repeat("\x1b[38;5;196;48;5;21m▀", 2) + "\x1b[0m"},
+		{mode: spriteASCII, expected: "##"},
+	}
+
+	for _, test := range tests {
+		lines := renderSprite(img, test.mode)
+		if len(lines) != 1 || lines[0] != test.expected {
+			t.Errorf("Mode: %s - Expected [%q], got %q", test.mode, test.expected, lines)
+		}
+	}
+}
+
+func TestRenderSpriteHalfBlocks(t *testing.T) {
+	img := image.NewNRGBA(image.Rect(0, 0, 2, 3))
+	img.Set(0, 0, color.NRGBA{G: 255, A: 255})
+	img.Set(1, 1, color.NRGBA{G: 255, A: 255})
+	img.Set(0, 2, color.NRGBA{A: 255})
+
+	expected := []string{
+		"\x1b[0;38;2;0;255;0m▀\x1b[0;38;2;0;255;0m▄\x1b[0m",
+		"\x1b[0;38;2;0;0;0m▀\x1b[0m \x1b[0m",
+	}
+	lines := renderSprite(img, spriteTrueColor)
+	if strings.Join(lines, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected %q, got %q", expected, lines)
+	}
+
+	if lines := renderSprite(img, spriteASCII); len(lines) != 2 || lines[1] != "@" {
+		t.Errorf("Expected a black pixel to be drawn as @, got %q", lines)
+	}
+}
+
+func TestSpriteURL(t *testing.T) {
+	var p Pokemon
+	p.Sprites.FrontDefault = "default.png"
+	p.Sprites.FrontShiny = "shiny.png"
+	p.Sprites.FrontFemale = "female.png"
+
+	tests := []struct {
+		owned    OwnedPokemon
+		expected string
+	}{
+		{owned: OwnedPokemon{Gender: "male"}, expected: "default.png"},
+		{owned: OwnedPokemon{Gender: "female"}, expected: "female.png"},
+		{owned: OwnedPokemon{Gender: "female", Shiny: true}, expected: "shiny.png"},
+	}
+
+	for _, test := range tests {
+		if actual := spriteURL(p, &test.owned); actual != test.expected {
+			t.Errorf("Owned: %+v - Expected %s, got %s", test.owned, test.expected, actual)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package main
+
+import (
+	"fmt"
+)
+
+const (
+	maxIV      = 31
+	maxStatEVs = 252
+	maxEVs     = 510
+)
+
+// statNames lists the six stats in the order the games show them.
+var statNames = []string{"hp", "attack", "defense", "special-attack", "special-defense", "speed"}
+
+// natureModifiers are the stats a nature raises and lowers by 10%.
+// Neutral natures raise and lower the same stat, or nothing.
+type natureModifiers struct {
+	increased string
+	decreased string
+}
+
+// percent is the nature's effect on a stat, as a percentage.
+func (n natureModifiers) percent(stat string) int {
+	switch {
+	case n.increased == n.decreased:
+		return 100
+	case stat == n.increased:
+		return 110
+	case stat == n.decreased:
+		return 90
+	}
+	return 100
+}
+
+// loadNature returns the stat changes of a nature. Pokemon without a
+// nature are neutral.
+func loadNature(cfg *config, name string) (natureModifiers, error) {
+	if name == "" {
+		return natureModifiers{}, nil
+	}
+	if nature, ok := cfg.Natures[name]; ok {
+		return nature, nil
+	}
+	res, err := fetchNature(pokeAPIBaseURL+"nature/"+name, cfg.Cache)
+	if err != nil {
+		return natureModifiers{}, fmt.Errorf("could not load nature %s: %w", name, err)
+	}
+
+	nature := natureModifiers{}
+	if res.IncreasedStat != nil {
+		nature.increased = res.IncreasedStat.Name
+	}
+	if res.DecreasedStat != nil {
+		nature.decreased = res.DecreasedStat.Name
+	}
+	if cfg.Natures == nil {
+		cfg.Natures = make(map[string]natureModifiers)
+	}
+	cfg.Natures[name] = nature
+	return nature, nil
+}
+
+// rollNature picks one of PokeAPI's natures at random.
+func rollNature(cfg *config) (string, error) {
+	res, err := fetchResourceList(pokeAPIBaseURL+"nature?limit=100", cfg.Cache)
+	if err != nil {
+		return "", fmt.Errorf("could not load natures: %w", err)
+	}
+	if len(res.Results) == 0 {
+		return "", fmt.Errorf("no natures to choose from")
+	}
+	return res.Results[cfg.Rand.Intn(len(res.Results))].Name, nil
+}
+
+// rollIVs gives each stat a random individual value from 0 to 31.
+func rollIVs(intn func(int) int) map[string]int {
+	ivs := make(map[string]int, len(statNames))
+	for _, stat := range statNames {
+		ivs[stat] = intn(maxIV + 1)
+	}
+	return ivs
+}
+
+// calculateStat is the stat formula of the games since generation 3, with
+// the nature's effect given as a percentage.
+func calculateStat(base, iv, ev, level, naturePercent int, hp bool) int {
+	value := (2*base + iv + ev/4) * level / 100
+	if hp {
+		return value + level + 10
+	}
+	return (value + 5) * naturePercent / 100
+}
+
+// ownedStats computes the actual stats of an owned Pokemon at its level.
+func ownedStats(cfg *config, owned *OwnedPokemon) (map[string]int, error) {
+	nature, err := loadNature(cfg, owned.Nature)
+	if err != nil {
+		return nil, err
+	}
+	stats := make(map[string]int)
+	for _, stat := range cfg.Pokedex[owned.Species].Stats {
+		name := stat.Stat.Name
+		stats[name] = calculateStat(stat.BaseStat, owned.IVs[name], owned.EVs[name], owned.Level, nature.percent(name), name == "hp")
+	}
+	return stats, nil
+}
+
+// gainEffort adds the effort values of a defeated Pokemon to an owned
+// Pokemon's EVs, up to 252 per stat and 510 in total.
+func gainEffort(owned *OwnedPokemon, defeated Pokemon) {
+	total := 0
+	for _, ev := range owned.EVs {
+		total += ev
+	}
+	for _, stat := range defeated.Stats {
+		gain := min(stat.Effort, maxStatEVs-owned.EVs[stat.Stat.Name], maxEVs-total)
+		if gain <= 0 {
+			continue
+		}
+		if owned.EVs == nil {
+			owned.EVs = make(map[string]int)
+		}
+		owned.EVs[stat.Stat.Name] += gain
+		total += gain
+	}
+}
@@ -0,0 +1,76 @@
+package main
+
+import (
+	"testing"
+)
+
+func TestCalculateStat(t *testing.T) {
+	// The level 78 Adamant Garchomp from Bulbapedia's stat examples.
+	tests := []struct {
+		name          string
+		base, iv, ev  int
+		naturePercent int
+		expected      int
+	}{
+		{name: "hp", base: 108, iv: 24, ev: 74, naturePercent: 100, expected: 289},
+		{name: "attack", base: 130, iv: 12, ev: 190, naturePercent: 110, expected: 278},
+		{name: "defense", base: 95, iv: 30, ev: 91, naturePercent: 100, expected: 193},
+		{name: "special-attack", base: 80, iv: 16, ev: 48, naturePercent: 90, expected: 135},
+		{name: "special-defense", base: 85, iv: 23, ev: 84, naturePercent: 100, expected: 171},
+		{name: "speed", base: 102, iv: 5, ev: 23, naturePercent: 100, expected: 171},
+	}
+
+	for _, test := range tests {
+		actual := calculateStat(test.base, test.iv, test.ev, 78, test.naturePercent, test.name == "hp")
+		if actual != test.expected {
+			t.Errorf("Stat: %s - Expected %d, got %d", test.name, test.expected, actual)
+		}
+	}
+}
+
+func TestNaturePercent(t *testing.T) {
+	adamant := natureModifiers{increased: "attack", decreased: "special-attack"}
+	hardy := natureModifiers{increased: "attack", decreased: "attack"}
+
+	if adamant.percent("attack") != 110 || adamant.percent("special-attack") != 90 || adamant.percent("speed") != 100 {
+		t.Error("Expected adamant to raise attack and lower special attack")
+	}
+	if hardy.percent("attack") != 100 || (natureModifiers{}).percent("attack") != 100 {
+		t.Error("Expected neutral natures not to change stats")
+	}
+}
+
+func TestGainEffort(t *testing.T) {
+	defeated := testPokemon("machop", []string{"fighting"}, map[string]int{"attack": 80})
+	defeated.Stats[1].Effort = 1
+
+	owned := &OwnedPokemon{EVs: map[string]int{"attack": 251}}
+	gainEffort(owned, defeated)
+	gainEffort(owned, defeated)
+	if owned.EVs["attack"] != maxStatEVs {
+		t.Errorf("Expected attack EVs to stop at %d, got %d", maxStatEVs, owned.EVs["attack"])
+	}
+
+	owned = &OwnedPokemon{EVs: map[string]int{"hp": 252, "defense": 252, "speed": 5}}
+	gainEffort(owned, defeated)
+	if owned.EVs["attack"] != 1 {
+		t.Errorf("Expected 1 attack EV, got %d", owned.EVs["attack"])
+	}
+	gainEffort(owned, defeated)
+	if owned.EVs["attack"] != 1 {
+		t.Errorf("Expected EVs to stop at %d in total, got %d attack EVs", maxEVs, owned.EVs["attack"])
+	}
+}
+
+func TestRollIVs(t *testing.T) {
+	cfg := newTestConfig(t)
+	ivs := rollIVs(cfg.Rand.Intn)
+	if len(ivs) != len(statNames) {
+		t.Fatalf("Expected an IV for each of the %d stats, got %v", len(statNames), ivs)
+	}
+	for stat, iv := range ivs {
+		if iv < 0 || iv > maxIV {
+			t.Errorf("Stat: %s - IV %d is out of range", stat, iv)
+		}
+	}
+}
@@ -0,0 +1,159 @@
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// typeChart maps a defending type to the damage multiplier each attacking
+// type deals to it. Attacking types that are missing deal normal damage.
+type typeChart map[string]map[string]float64
+
+// multiplier returns the damage multiplier of an attacking type against a
+// Pokemon with the given types, e.g. 4 for ground against fire/steel.
+func (chart typeChart) multiplier(attacking string, defending []string) float64 {
+	m := 1.0
+	for _, t := range defending {
+		if relation, ok := chart[t][attacking]; ok {
+			m *= relation
+		}
+	}
+	return m
+}
+
+// attackingTypes lists every attacking type that doesn't deal normal
+// damage to at least one of the defending types.
+func (chart typeChart) attackingTypes(defending []string) []string {
+	seen := make(map[string]bool)
+	for _, t := range defending {
+		for attacking := range chart[t] {
+			seen[attacking] = true
+		}
+	}
+	types := make([]string, 0, len(seen))
+	for t := range seen {
+		types = append(types, t)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// loadTypeChart makes sure the damage relations of the given types are in
+// cfg.TypeChart. Type data never changes, so unlike responses in the HTTP
+// cache it is kept for the whole session.
+func loadTypeChart(cfg *config, types ...string) error {
+	if cfg.TypeChart == nil {
+		cfg.TypeChart = make(typeChart)
+	}
+	for _, t := range types {
+		if _, ok := cfg.TypeChart[t]; ok {
+			continue
+		}
+		res, err := fetchType(pokeAPIBaseURL+"type/"+t, cfg.Cache)
+		if err != nil {
+			return fmt.Errorf("could not load type %s: %w", t, err)
+		}
+
+		relations := make(map[string]float64)
+		for _, r := range res.DamageRelations.DoubleDamageFrom {
+			relations[r.Name] = 2
+		}
+		for _, r := range res.DamageRelations.HalfDamageFrom {
+			relations[r.Name] = 0.5
+		}
+		for _, r := range res.DamageRelations.NoDamageFrom {
+			relations[r.Name] = 0
+		}
+		cfg.TypeChart[t] = relations
+	}
+	return nil
+}
+
+func pokemonTypes(p Pokemon) []string {
+	types := make([]string, len(p.Types))
+	for i, t := range p.Types {
+		types[i] = t.Type.Name
+	}
+	return types
+}
+
+func commandMatchup(cfg *config, args []string) error {
+	if len(args) == 2 || (len(args) == 3 && strings.ToLower(args[1]) != "vs") {
+		return fmt.Errorf("usage: matchup <pokemon> [vs <pokemon>]")
+	}
+
+	pokemon, err := lookupPokemon(cfg, resourceName(args[0]))
+	if err != nil {
+		return err
+	}
+	types := pokemonTypes(pokemon)
+	if err := loadTypeChart(cfg, types...); err != nil {
+		return err
+	}
+
+	if len(args) == 1 {
+		printTypeMatchups(cfg, pokemon.Name, types)
+		return nil
+	}
+
+	opponent, err := lookupPokemon(cfg, resourceName(args[len(args)-1]))
+	if err != nil {
+		return err
+	}
+	opponentTypes := pokemonTypes(opponent)
+	if err := loadTypeChart(cfg, opponentTypes...); err != nil {
+		return err
+	}
+
+	printAttackMatchups(cfg, pokemon.Name, types, opponent.Name, opponentTypes)
+	printAttackMatchups(cfg, opponent.Name, opponentTypes, pokemon.Name, types)
+	return nil
+}
+
+func printTypeMatchups(cfg *config, name string, types []string) {
+	var weaknesses, resistances, immunities []string
+	for _, attacking := range cfg.TypeChart.attackingTypes(types) {
+		m := cfg.TypeChart.multiplier(attacking, types)
+		shown := cfg.localize("type", attacking)
+		entry := fmt.Sprintf("%s (%sx)", shown, formatMultiplier(m))
+		switch {
+		case m == 0:
+			immunities = append(immunities, shown)
+		case m > 1:
+			weaknesses = append(weaknesses, entry)
+		case m < 1:
+			resistances = append(resistances, entry)
+		}
+	}
+
+	fmt.Printf("%s (%s)\n", cfg.localize("pokemon", name), strings.Join(cfg.localizeAll("type", types), "/"))
+	printTypeList("Weak to", weaknesses)
+	printTypeList("Resists", resistances)
+	printTypeList("Immune to", immunities)
+}
+
+func printTypeList(title string, entries []string) {
+	if len(entries) == 0 {
+		entries = []string{"none"}
+	}
+	fmt.Printf("  %s: %s\n", title, strings.Join(entries, ", "))
+}
+
+func printAttackMatchups(cfg *config, attacker string, attackerTypes []string, defender string, defenderTypes []string) {
+	fmt.Printf("%s's attacks against %s (%s):\n", cfg.localize("pokemon", attacker), cfg.localize("pokemon", defender),
+		strings.Join(cfg.localizeAll("type", defenderTypes), "/"))
+	for _, t := range attackerTypes {
+		fmt.Printf("  - %s: %sx\n", cfg.localize("type", t), formatMultiplier(cfg.TypeChart.multiplier(t, defenderTypes)))
+	}
+}
+
+func formatMultiplier(m float64) string {
+	switch m {
+	case 0.25:
+		return "1/4"
+	case 0.5:
+		return "1/2"
+	}
+	return fmt.Sprintf("%g", m)
+}
@@ -0,0 +1,81 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+func TestTypeChartMultiplier(t *testing.T) {
+	chart := typeChart{
+		"fire":   {"water": 2, "ground": 2, "rock": 2, "fire": 0.5, "grass": 0.5, "steel": 0.5},
+		"steel":  {"fire": 2, "ground": 2, "fighting": 2, "steel": 0.5, "grass": 0.5, "poison": 0},
+		"flying": {"rock": 2, "electric": 2, "grass": 0.5, "ground": 0},
+	}
+
+	tests := []struct {
+		attacking string
+		defending []string
+		expected  float64
+	}{
+		{attacking: "water", defending: []string{"fire"}, expected: 2},
+		{attacking: "ground", defending: []string{"fire", "steel"}, expected: 4},
+		{attacking: "grass", defending: []string{"fire", "steel"}, expected: 0.25},
+		{attacking: "fire", defending: []string{"fire", "steel"}, expected: 1},
+		{attacking: "ground", defending: []string{"fire", "flying"}, expected: 0},
+		{attacking: "normal", defending: []string{"fire"}, expected: 1},
+		{attacking: "water", defending: []string{"unknown"}, expected: 1},
+	}
+
+	for _, test := range tests {
+		actual := chart.multiplier(test.attacking, test.defending)
+		if actual != test.expected {
+			t.Errorf("%s vs %v - Expected %g, got %g", test.attacking, test.defending, test.expected, actual)
+		}
+	}
+
+	expected := []string{"electric", "fighting", "fire", "grass", "ground", "poison", "rock", "steel"}
+	if actual := chart.attackingTypes([]string{"steel", "flying"}); !reflect.DeepEqual(actual, expected) {
+		t.Errorf("Expected attacking types %v, got %v", expected, actual)
+	}
+}
+
+func TestLoadTypeChart(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"type/ghost", []byte(`{
+		"name": "ghost",
+		"damage_relations": {
+			"double_damage_from": [{"name": "ghost"}, {"name": "dark"}],
+			"half_damage_from": [{"name": "poison"}, {"name": "bug"}],
+			"no_damage_from": [{"name": "normal"}, {"name": "fighting"}],
+			"double_damage_to": [{"name": "psychic"}]
+		}
+	}`))
+
+	if err := loadTypeChart(cfg, "ghost"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]float64{"ghost": 2, "dark": 2, "poison": 0.5, "bug": 0.5, "normal": 0, "fighting": 0}
+	if !reflect.DeepEqual(cfg.TypeChart["ghost"], expected) {
+		t.Errorf("Expected relations %v, got %v", expected, cfg.TypeChart["ghost"])
+	}
+}
+
+func TestCommandMatchupUsage(t *testing.T) {
+	cfg := newTestConfig(t)
+	for _, args := range [][]string{
+		{"charizard", "vs"},
+		{"charizard", "blastoise"},
+		{"charizard", "and", "blastoise"},
+	} {
+		err := commandMatchup(cfg, args)
+		if err == nil || !strings.HasPrefix(err.Error(), "usage:") {
+			t.Errorf("Args: %q - Expected a usage error, got %v", args, err)
+		}
+	}
+}
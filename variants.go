@@ -0,0 +1,55 @@
+package main
+
+// shinyOdds is the chance of a shiny Pokemon, 1 in 4096 as in the games
+// since generation 6.
+const shinyOdds = 4096
+
+// rollGender picks a gender using the species' gender rate, the chance of
+// being female in eighths, or -1 for genderless species.
+func rollGender(genderRate int, intn func(int) int) string {
+	switch {
+	case genderRate < 0:
+		return "genderless"
+	case intn(8) < genderRate:
+		return "female"
+	}
+	return "male"
+}
+
+func rollShiny(intn func(int) int) bool {
+	return intn(shinyOdds) == 0
+}
+
+// rollForm picks one of a Pokemon's cosmetic forms, or returns "" if it
+// only has one.
+func rollForm(p Pokemon, intn func(int) int) string {
+	if len(p.Forms) < 2 {
+		return ""
+	}
+	return p.Forms[intn(len(p.Forms))].Name
+}
+
+// defaultVarietyURL is the URL of the Pokemon that represents a species,
+// e.g. deoxys-normal for deoxys.
+func defaultVarietyURL(species *speciesResponse) string {
+	for _, variety := range species.Varieties {
+		if variety.IsDefault {
+			return variety.Pokemon.URL
+		}
+	}
+	return pokeAPIBaseURL + "pokemon/" + species.Name
+}
+
+// fetchCatchable fetches a Pokemon by name, or the default variety of a
+// species for species names that aren't Pokemon names, like deoxys.
+func fetchCatchable(cfg *config, name string) (*pokemonDetailResponse, error) {
+	pokemon, err := fetchPokemon(pokeAPIBaseURL+"pokemon/"+name, cfg.Cache)
+	if err == nil {
+		return pokemon, nil
+	}
+	species, speciesErr := fetchSpecies(pokeAPIBaseURL+"pokemon-species/"+name, cfg.Cache)
+	if speciesErr != nil {
+		return nil, err
+	}
+	return fetchPokemon(defaultVarietyURL(species), cfg.Cache)
+}
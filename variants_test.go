@@ -0,0 +1,73 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Fearcon14/pokedexCLI/internal/pokecache"
+)
+
+func TestRollGender(t *testing.T) {
+	always := func(n int) func(int) int {
+		return func(int) int { return n }
+	}
+	tests := []struct {
+		genderRate int
+		roll       int
+		expected   string
+	}{
+		{genderRate: -1, roll: 0, expected: "genderless"},
+		{genderRate: 0, roll: 0, expected: "male"},
+		{genderRate: 1, roll: 0, expected: "female"},
+		{genderRate: 1, roll: 1, expected: "male"},
+		{genderRate: 4, roll: 3, expected: "female"},
+		{genderRate: 8, roll: 7, expected: "female"},
+	}
+
+	for _, test := range tests {
+		if actual := rollGender(test.genderRate, always(test.roll)); actual != test.expected {
+			t.Errorf("Gender rate: %d, roll: %d - Expected %s, got %s", test.genderRate, test.roll, test.expected, actual)
+		}
+	}
+}
+
+func TestRollShinyAndForm(t *testing.T) {
+	if !rollShiny(func(n int) int { return 0 }) || rollShiny(func(n int) int { return n - 1 }) {
+		t.Error("Expected only a roll of 0 to be shiny")
+	}
+
+	var unown Pokemon
+	if form := rollForm(unown, func(int) int { return 0 }); form != "" {
+		t.Errorf("Expected no form for a Pokemon without forms, got %s", form)
+	}
+	for _, name := range []string{"unown-a", "unown-b"} {
+		unown.Forms = append(unown.Forms, struct {
+			Name string
+			URL  string
+		}{Name: name})
+	}
+	if form := rollForm(unown, func(int) int { return 1 }); form != "unown-b" {
+		t.Errorf("Expected unown-b, got %s", form)
+	}
+}
+
+func TestFetchCatchableUsesDefaultVariety(t *testing.T) {
+	cfg := newTestConfig(t)
+	cfg.Cache = pokecache.NewCache(time.Minute)
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon-species/deoxys", []byte(`{
+		"name": "deoxys",
+		"varieties": [
+			{"is_default": true, "pokemon": {"name": "deoxys-normal", "url": "https://pokeapi.co/api/v2/pokemon/386/"}},
+			{"is_default": false, "pokemon": {"name": "deoxys-attack", "url": "https://pokeapi.co/api/v2/pokemon/10001/"}}
+		]
+	}`))
+	cfg.Cache.Add(pokeAPIBaseURL+"pokemon/386/", []byte(`{"name": "deoxys-normal"}`))
+
+	pokemon, err := fetchCatchable(cfg, "deoxys")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if pokemon.Name != "deoxys-normal" {
+		t.Errorf("Expected deoxys-normal, got %s", pokemon.Name)
+	}
+}